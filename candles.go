@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+	"trading-platform-backend/repository"
+	"trading-platform-backend/services"
+)
+
+const candlesUsage = `Usage: trading-platform-backend candles <command>
+
+Commands:
+  import <file.csv>   backfill candles (columns: symbol, interval, open_time,
+                      open, high, low, close, volume; open_time in RFC 3339)
+  prune               delete candles older than the configured retention`
+
+func runCandles(args []string) {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, candlesUsage)
+		os.Exit(2)
+	}
+
+	cfg, _ := loadConfig()
+	db := openDatabase(cfg)
+	ctx := context.Background()
+
+	instrumentService := services.NewInstrumentService(repository.NewGormInstrumentRepository(db))
+	if err := instrumentService.Refresh(ctx); err != nil {
+		fatal("Failed to load instruments", err)
+	}
+	calendar, err := services.LoadMarketCalendar(cfg.MarketConfig)
+	if err != nil {
+		fatal("Failed to load market calendar", err)
+	}
+	candleService := services.NewCandleService(repository.NewGormCandleRepository(db), instrumentService, calendar, cfg.CandleConfig)
+
+	switch {
+	case args[0] == "import" && len(args) == 2:
+		f, err := os.Open(args[1])
+		if err != nil {
+			fatal("Failed to open candles file", err)
+		}
+		defer f.Close()
+
+		imported, err := candleService.ImportCSV(ctx, f)
+		if err != nil {
+			fatal("Failed to import candles", err)
+		}
+		fmt.Printf("Imported %d candles\n", imported)
+	case args[0] == "prune" && len(args) == 1:
+		deleted, err := candleService.Prune(ctx, time.Now())
+		if err != nil {
+			fatal("Failed to prune candles", err)
+		}
+		fmt.Printf("Deleted %d expired candles\n", deleted)
+	default:
+		fmt.Fprintln(os.Stderr, candlesUsage)
+		os.Exit(2)
+	}
+}
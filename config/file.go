@@ -0,0 +1,63 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// fileSource is a parsed configuration file. YAML and TOML documents are both
+// normalised to a generic map and decoded through YAML, so durations such as
+// "10m" and unknown-key detection behave the same for either format.
+type fileSource struct {
+	path string
+	data map[string]any
+}
+
+func readFile(path string) (*fileSource, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read config file: %w", err)
+	}
+
+	data := map[string]any{}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(raw, &data)
+	case ".toml":
+		err = toml.Unmarshal(raw, &data)
+	default:
+		return nil, fmt.Errorf("config file %s: unsupported format (use .yaml, .yml or .toml)", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("config file %s: %w", path, err)
+	}
+
+	return &fileSource{path: path, data: data}, nil
+}
+
+func (f *fileSource) environment() string {
+	env, _ := f.data["environment"].(string)
+	return env
+}
+
+// decode overlays the file values onto cfg; keys absent from the file keep
+// their current values
+func (f *fileSource) decode(cfg *Config) error {
+	normalised, err := yaml.Marshal(f.data)
+	if err != nil {
+		return fmt.Errorf("config file %s: %w", f.path, err)
+	}
+
+	dec := yaml.NewDecoder(bytes.NewReader(normalised))
+	dec.KnownFields(true)
+	if err := dec.Decode(cfg); err != nil {
+		return fmt.Errorf("config file %s: %w", f.path, err)
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+package config
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// Manager holds the live configuration and applies safe-to-change sections
+// (rate limits, circuit breaker thresholds, trading halts, risk limits) on
+// reload. Every other setting requires a restart and is left untouched.
+type Manager struct {
+	path    string
+	current atomic.Pointer[Config]
+
+	mu        sync.Mutex
+	listeners []func(*Config)
+}
+
+func NewManager(path string, cfg *Config) *Manager {
+	m := &Manager{path: path}
+	m.current.Store(cfg)
+	return m
+}
+
+// Current returns the active configuration. Callers must treat it as read-only.
+func (m *Manager) Current() *Config {
+	return m.current.Load()
+}
+
+// OnReload registers fn to be called with the new configuration after every
+// successful reload
+func (m *Manager) OnReload(fn func(*Config)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.listeners = append(m.listeners, fn)
+}
+
+// Reload re-reads the file and environment. An invalid configuration is
+// rejected as a whole and the previous one stays active.
+func (m *Manager) Reload() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	loaded, err := LoadFile(m.path)
+	if err != nil {
+		return err
+	}
+
+	old := m.current.Load()
+	next := *old
+	next.RateLimitConfig = loaded.RateLimitConfig
+	next.CircuitBreakerConfig = loaded.CircuitBreakerConfig
+	next.TradingConfig = loaded.TradingConfig
+	next.RiskConfig = loaded.RiskConfig
+
+	// Report changes that were ignored because they need a restart
+	restartOnly := *loaded
+	restartOnly.RateLimitConfig = old.RateLimitConfig
+	restartOnly.CircuitBreakerConfig = old.CircuitBreakerConfig
+	restartOnly.TradingConfig = old.TradingConfig
+	restartOnly.RiskConfig = old.RiskConfig
+	if !reflect.DeepEqual(&restartOnly, old) {
+		slog.Warn("Configuration changes outside rate_limits, circuit_breaker, trading and risk require a restart and were ignored")
+	}
+
+	m.current.Store(&next)
+	for _, fn := range m.listeners {
+		fn(&next)
+	}
+
+	slog.Info("Configuration reloaded",
+		"rate_limits", next.RateLimitConfig,
+		"circuit_breaker", next.CircuitBreakerConfig,
+		"trading", next.TradingConfig,
+		"risk", next.RiskConfig,
+	)
+	return nil
+}
+
+// Watch reloads on SIGHUP and, when a config file is in use, whenever that
+// file changes. It blocks until ctx is cancelled.
+func (m *Manager) Watch(ctx context.Context) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	var fileEvents <-chan fsnotify.Event
+	if m.path != "" {
+		watcher, err := fsnotify.NewWatcher()
+		if err != nil {
+			slog.Error("Config file watcher unavailable", "error", err)
+		} else {
+			defer watcher.Close()
+			// Watch the directory so editors that replace the file atomically are seen
+			if err := watcher.Add(filepath.Dir(m.path)); err != nil {
+				slog.Error("Config file watcher unavailable", "path", m.path, "error", err)
+			} else {
+				fileEvents = watcher.Events
+			}
+		}
+	}
+
+	// Editors emit several events per save; coalesce them into one reload
+	var debounce <-chan time.Time
+	target := filepath.Clean(m.path)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hup:
+			m.reloadAndLog("SIGHUP")
+		case event := <-fileEvents:
+			if filepath.Clean(event.Name) == target && event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Rename) != 0 {
+				debounce = time.After(200 * time.Millisecond)
+			}
+		case <-debounce:
+			debounce = nil
+			m.reloadAndLog("file change")
+		}
+	}
+}
+
+func (m *Manager) reloadAndLog(trigger string) {
+	if err := m.Reload(); err != nil {
+		slog.Error("Configuration reload rejected", "trigger", trigger, "error", err)
+	}
+}
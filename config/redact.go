@@ -0,0 +1,106 @@
+package config
+
+import (
+	"log/slog"
+	"net/url"
+)
+
+const redacted = "[REDACTED]"
+
+// LogValue renders the effective configuration for startup logs with secrets
+// and credentials in connection URLs replaced
+func (c *Config) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("environment", c.Environment),
+		slog.String("port", c.Port),
+		slog.String("database_url", redactURL(c.DatabaseURL)),
+		slog.Bool("db_auto_migrate", c.DBAutoMigrate),
+		slog.String("redis_url", redactURL(c.RedisURL)),
+		slog.String("redis_password", redactSecret(c.RedisPassword)),
+		slog.String("jwt_secret", redactSecret(c.JWTSecret)),
+		slog.String("jwt_refresh_secret", redactSecret(c.JWTRefreshSecret)),
+		slog.String("jwt_expires_in", c.JWTExpiresIn.String()),
+		slog.String("jwt_refresh_expires_in", c.JWTRefreshExpiresIn.String()),
+		slog.String("log_level", c.LogLevel),
+		slog.String("log_format", c.LogFormat),
+		slog.Group("circuit_breaker",
+			slog.String("timeout", c.CircuitBreakerConfig.Timeout.String()),
+			slog.Int("error_threshold", c.CircuitBreakerConfig.ErrorThreshold),
+			slog.Float64("failure_ratio", c.CircuitBreakerConfig.FailureRatio),
+			slog.String("interval", c.CircuitBreakerConfig.Interval.String()),
+			slog.String("reset_timeout", c.CircuitBreakerConfig.ResetTimeout.String()),
+			slog.Int("half_open_requests", c.CircuitBreakerConfig.HalfOpenRequests),
+		),
+		slog.Group("rate_limits",
+			slog.Int("login_max_requests", c.RateLimitConfig.Login.MaxRequests),
+			slog.String("login_window", c.RateLimitConfig.Login.Window.String()),
+		),
+		slog.Group("trading",
+			slog.Bool("halted", c.TradingConfig.Halted),
+			slog.Any("halted_symbols", c.TradingConfig.HaltedSymbols),
+		),
+		slog.Group("risk",
+			slog.Float64("max_order_value", c.RiskConfig.Default.MaxOrderValue),
+			slog.Int("max_order_quantity", c.RiskConfig.Default.MaxOrderQuantity),
+			slog.Float64("max_price_deviation", c.RiskConfig.Default.MaxPriceDeviation),
+			slog.Int("max_open_orders", c.RiskConfig.Default.MaxOpenOrders),
+			slog.Int("max_position_quantity", c.RiskConfig.Default.MaxPositionQuantity),
+			slog.Float64("daily_loss_limit", c.RiskConfig.Default.DailyLossLimit),
+			slog.Any("restricted_symbols", c.RiskConfig.Default.RestrictedSymbols),
+			slog.Int("role_overrides", len(c.RiskConfig.Roles)),
+			slog.Int("user_overrides", len(c.RiskConfig.Users)),
+		),
+		slog.Group("margin",
+			slog.Float64("starting_cash", c.MarginConfig.StartingCash),
+			slog.Float64("default_rate", c.MarginConfig.DefaultRate),
+			slog.Float64("maintenance_ratio", c.MarginConfig.MaintenanceRatio),
+		),
+		slog.Group("liquidation",
+			slog.String("interval", c.LiquidationConfig.Interval.String()),
+			slog.Float64("margin_ratio", c.LiquidationConfig.MarginRatio),
+			slog.String("square_off_before", c.LiquidationConfig.SquareOffBefore.String()),
+		),
+		slog.Group("simulator",
+			slog.String("tick_interval", c.SimulatorConfig.TickInterval.String()),
+			slog.Float64("volatility", c.SimulatorConfig.Volatility),
+			slog.String("circuit_halt", c.SimulatorConfig.CircuitHalt.String()),
+		),
+		slog.Group("candles",
+			slog.String("flush_interval", c.CandleConfig.FlushInterval.String()),
+			slog.String("minute_retention", c.CandleConfig.MinuteRetention.String()),
+			slog.String("hourly_retention", c.CandleConfig.HourlyRetention.String()),
+			slog.String("daily_retention", c.CandleConfig.DailyRetention.String()),
+		),
+		slog.Group("market",
+			slog.String("timezone", c.MarketConfig.Timezone),
+			slog.String("holidays_file", c.MarketConfig.HolidaysFile),
+			slog.Bool("always_open", c.MarketConfig.AlwaysOpen),
+		),
+		slog.Group("tracing",
+			slog.String("exporter", c.TracingConfig.Exporter),
+			slog.String("otlp_endpoint", c.TracingConfig.OTLPEndpoint),
+			slog.Float64("sample_ratio", c.TracingConfig.SampleRatio),
+			slog.String("service_name", c.TracingConfig.ServiceName),
+		),
+		slog.Group("cors",
+			slog.Any("allowed_origins", c.CORSConfig.AllowedOrigins),
+			slog.Bool("allow_credentials", c.CORSConfig.AllowCredentials),
+			slog.String("max_age", c.CORSConfig.MaxAge.String()),
+		),
+	)
+}
+
+func redactSecret(secret string) string {
+	if secret == "" {
+		return ""
+	}
+	return redacted
+}
+
+func redactURL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return redacted
+	}
+	return u.Redacted()
+}
@@ -0,0 +1,281 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MinSecretLength is the shortest JWT secret accepted in production
+const MinSecretLength = 32
+
+// ValidationError aggregates every configuration problem found at startup so
+// operators can fix them in one pass
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return "invalid configuration:\n  - " + strings.Join(e.Problems, "\n  - ")
+}
+
+// envParser converts environment variables to typed values, recording a
+// descriptive problem instead of silently falling back to zero values
+type envParser struct {
+	problems []string
+}
+
+func (p *envParser) duration(key string, defaultValue time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		p.problems = append(p.problems, fmt.Sprintf("%s: invalid duration %q (use a unit, e.g. \"10m\", \"30s\", \"168h\")", key, value))
+		return defaultValue
+	}
+	return d
+}
+
+func (p *envParser) int(key string, defaultValue int) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		p.problems = append(p.problems, fmt.Sprintf("%s: invalid integer %q", key, value))
+		return defaultValue
+	}
+	return n
+}
+
+func (p *envParser) float(key string, defaultValue float64) float64 {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		p.problems = append(p.problems, fmt.Sprintf("%s: invalid number %q", key, value))
+		return defaultValue
+	}
+	return f
+}
+
+func (p *envParser) bool(key string, defaultValue bool) bool {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		p.problems = append(p.problems, fmt.Sprintf("%s: invalid boolean %q (use true or false)", key, value))
+		return defaultValue
+	}
+	return b
+}
+
+// IsProduction reports whether the service runs with production safeguards
+func (c *Config) IsProduction() bool {
+	return c.Environment == "production"
+}
+
+// validate checks semantic constraints on already parsed values
+func (c *Config) validate() []string {
+	var problems []string
+	add := func(format string, args ...any) {
+		problems = append(problems, fmt.Sprintf(format, args...))
+	}
+
+	if !slices.Contains([]string{"development", "test", "staging", "production"}, c.Environment) {
+		add("GO_ENV: unknown environment %q (expected development, test, staging or production)", c.Environment)
+	}
+	if port, err := strconv.Atoi(c.Port); err != nil || port < 1 || port > 65535 {
+		add("PORT: %q is not a valid TCP port", c.Port)
+	}
+	if _, err := url.Parse(c.DatabaseURL); err != nil || c.DatabaseURL == "" {
+		add("DATABASE_URL: must be a valid connection URL")
+	}
+	if u, err := url.Parse(c.RedisURL); err != nil || (u.Scheme != "redis" && u.Scheme != "rediss") {
+		add("REDIS_URL: must be a redis:// or rediss:// URL")
+	}
+
+	// Token lifetimes
+	if c.JWTExpiresIn < time.Minute {
+		add("JWT_EXPIRES_IN: must be at least 1m, got %s", c.JWTExpiresIn)
+	}
+	if c.JWTRefreshExpiresIn <= c.JWTExpiresIn {
+		add("JWT_REFRESH_EXPIRES_IN: must be longer than JWT_EXPIRES_IN (%s <= %s)", c.JWTRefreshExpiresIn, c.JWTExpiresIn)
+	}
+	if c.JWTSecret == "" {
+		add("JWT_SECRET: must not be empty")
+	}
+	if c.JWTRefreshSecret == "" {
+		add("JWT_REFRESH_SECRET: must not be empty")
+	}
+
+	// Secrets policy for production deployments
+	if c.IsProduction() {
+		if c.JWTSecret == DefaultJWTSecret {
+			add("JWT_SECRET: the built-in default secret is not allowed in production")
+		} else if len(c.JWTSecret) < MinSecretLength {
+			add("JWT_SECRET: must be at least %d characters in production", MinSecretLength)
+		}
+		if c.JWTRefreshSecret == DefaultJWTRefreshSecret {
+			add("JWT_REFRESH_SECRET: the built-in default secret is not allowed in production")
+		} else if len(c.JWTRefreshSecret) < MinSecretLength {
+			add("JWT_REFRESH_SECRET: must be at least %d characters in production", MinSecretLength)
+		}
+		if c.JWTSecret == c.JWTRefreshSecret {
+			add("JWT_REFRESH_SECRET: must differ from JWT_SECRET")
+		}
+		if slices.Contains(c.CORSConfig.AllowedOrigins, "*") {
+			add("CORS_ALLOWED_ORIGINS: wildcard origin is not allowed in production")
+		}
+		if c.DBAutoMigrate {
+			add("DB_AUTO_MIGRATE: automatic migrations are not allowed in production; run the migrate command instead")
+		}
+	}
+
+	// Circuit breaker
+	if c.CircuitBreakerConfig.Timeout <= 0 {
+		add("CIRCUIT_BREAKER_TIMEOUT: must be positive")
+	}
+	if c.CircuitBreakerConfig.ErrorThreshold < 1 {
+		add("CIRCUIT_BREAKER_ERROR_THRESHOLD: must be at least 1")
+	}
+	if c.CircuitBreakerConfig.FailureRatio <= 0 || c.CircuitBreakerConfig.FailureRatio > 1 {
+		add("CIRCUIT_BREAKER_FAILURE_RATIO: must be above 0 and at most 1, got %g", c.CircuitBreakerConfig.FailureRatio)
+	}
+	if c.CircuitBreakerConfig.Interval < 0 {
+		add("CIRCUIT_BREAKER_INTERVAL: must not be negative")
+	}
+	if c.CircuitBreakerConfig.ResetTimeout <= 0 {
+		add("CIRCUIT_BREAKER_RESET_TIMEOUT: must be positive")
+	}
+	if c.CircuitBreakerConfig.HalfOpenRequests < 1 {
+		add("CIRCUIT_BREAKER_HALF_OPEN_REQUESTS: must be at least 1")
+	}
+
+	// Rate limits
+	if c.RateLimitConfig.Login.MaxRequests < 1 {
+		add("RATE_LIMIT_LOGIN_MAX_REQUESTS: must be at least 1")
+	}
+	if c.RateLimitConfig.Login.Window <= 0 {
+		add("RATE_LIMIT_LOGIN_WINDOW: must be positive")
+	}
+
+	// Risk limits
+	c.RiskConfig.Default.validate("RISK", add)
+	for role, limits := range c.RiskConfig.Roles {
+		limits.validate("risk.roles."+role, add)
+	}
+	for email, limits := range c.RiskConfig.Users {
+		limits.validate("risk.users."+email, add)
+	}
+
+	// Margin
+	if c.MarginConfig.StartingCash < 0 {
+		add("MARGIN_STARTING_CASH: must not be negative")
+	}
+	if c.MarginConfig.DefaultRate <= 0 || c.MarginConfig.DefaultRate > 100 {
+		add("MARGIN_DEFAULT_RATE: must be above 0 and at most 100 percent, got %g", c.MarginConfig.DefaultRate)
+	}
+	if c.MarginConfig.MaintenanceRatio <= 0 || c.MarginConfig.MaintenanceRatio > 100 {
+		add("MARGIN_MAINTENANCE_RATIO: must be above 0 and at most 100 percent, got %g", c.MarginConfig.MaintenanceRatio)
+	}
+
+	// Liquidation
+	if c.LiquidationConfig.Interval < time.Second {
+		add("LIQUIDATION_INTERVAL: must be at least 1s, got %s", c.LiquidationConfig.Interval)
+	}
+	if c.LiquidationConfig.MarginRatio <= 0 || c.LiquidationConfig.MarginRatio > c.MarginConfig.MaintenanceRatio {
+		add("LIQUIDATION_MARGIN_RATIO: must be above 0 and at most MARGIN_MAINTENANCE_RATIO (%g), got %g",
+			c.MarginConfig.MaintenanceRatio, c.LiquidationConfig.MarginRatio)
+	}
+	if c.LiquidationConfig.SquareOffBefore < 0 || c.LiquidationConfig.SquareOffBefore >= 6*time.Hour {
+		add("LIQUIDATION_SQUARE_OFF_BEFORE: must be between 0 and 6h, got %s", c.LiquidationConfig.SquareOffBefore)
+	}
+
+	// Price simulator
+	if c.SimulatorConfig.TickInterval < 10*time.Millisecond {
+		add("SIMULATOR_TICK_INTERVAL: must be at least 10ms, got %s", c.SimulatorConfig.TickInterval)
+	}
+	if c.SimulatorConfig.Volatility < 0 || c.SimulatorConfig.Volatility > 0.1 {
+		add("SIMULATOR_VOLATILITY: must be between 0 and 0.1, got %g", c.SimulatorConfig.Volatility)
+	}
+	if c.SimulatorConfig.CircuitHalt < 0 {
+		add("SIMULATOR_CIRCUIT_HALT: must not be negative")
+	}
+
+	// Candles
+	if c.CandleConfig.FlushInterval < time.Second {
+		add("CANDLE_FLUSH_INTERVAL: must be at least 1s, got %s", c.CandleConfig.FlushInterval)
+	}
+	retentions := []struct {
+		name  string
+		value time.Duration
+	}{
+		{"CANDLE_MINUTE_RETENTION", c.CandleConfig.MinuteRetention},
+		{"CANDLE_HOURLY_RETENTION", c.CandleConfig.HourlyRetention},
+		{"CANDLE_DAILY_RETENTION", c.CandleConfig.DailyRetention},
+	}
+	for _, r := range retentions {
+		if r.value < 0 || (r.value > 0 && r.value < 24*time.Hour) {
+			add("%s: must be 0 (keep forever) or at least 24h, got %s", r.name, r.value)
+		}
+	}
+
+	// Market calendar
+	if _, err := time.LoadLocation(c.MarketConfig.Timezone); err != nil || c.MarketConfig.Timezone == "" {
+		add("MARKET_TIMEZONE: unknown time zone %q", c.MarketConfig.Timezone)
+	}
+	if c.MarketConfig.HolidaysFile != "" {
+		if _, err := os.Stat(c.MarketConfig.HolidaysFile); err != nil {
+			add("MARKET_HOLIDAYS_FILE: %v", err)
+		}
+	}
+	if c.IsProduction() && c.MarketConfig.AlwaysOpen {
+		add("MARKET_ALWAYS_OPEN: must not be enabled in production")
+	}
+
+	// Logging and tracing
+	if !slices.Contains([]string{"debug", "info", "warn", "warning", "error"}, strings.ToLower(c.LogLevel)) {
+		add("LOG_LEVEL: unknown level %q (expected debug, info, warn or error)", c.LogLevel)
+	}
+	if !slices.Contains([]string{"json", "text"}, strings.ToLower(c.LogFormat)) {
+		add("LOG_FORMAT: unknown format %q (expected json or text)", c.LogFormat)
+	}
+	if !slices.Contains([]string{"none", "stdout", "otlp"}, strings.ToLower(c.TracingConfig.Exporter)) {
+		add("OTEL_TRACES_EXPORTER: unknown exporter %q (expected none, stdout or otlp)", c.TracingConfig.Exporter)
+	}
+	if c.TracingConfig.SampleRatio < 0 || c.TracingConfig.SampleRatio > 1 {
+		add("OTEL_TRACES_SAMPLE_RATIO: must be between 0 and 1, got %g", c.TracingConfig.SampleRatio)
+	}
+
+	// CORS
+	if c.CORSConfig.AllowCredentials && slices.Contains(c.CORSConfig.AllowedOrigins, "*") {
+		add("CORS_ALLOW_CREDENTIALS: credentials cannot be combined with a wildcard origin")
+	}
+	if c.CORSConfig.MaxAge < 0 {
+		add("CORS_MAX_AGE: must not be negative")
+	}
+
+	return problems
+}
+
+// validate reports negative limits and deviations of 100% or more under prefix
+func (l RiskLimits) validate(prefix string, add func(format string, args ...any)) {
+	if l.MaxOrderValue < 0 || l.MaxOrderQuantity < 0 || l.MaxOpenOrders < 0 || l.MaxPositionQuantity < 0 || l.DailyLossLimit < 0 {
+		add("%s: limits must not be negative", prefix)
+	}
+	if l.MaxPriceDeviation < 0 || l.MaxPriceDeviation >= 100 {
+		add("%s: max price deviation must be between 0 and 100 percent, got %g", prefix, l.MaxPriceDeviation)
+	}
+}
@@ -0,0 +1,15 @@
+// Package data embeds the reference data shipped with the binary
+package data
+
+import _ "embed"
+
+// InstrumentsCSV is the default instrument master loaded by the seed command
+//
+//go:embed instruments.csv
+var InstrumentsCSV []byte
+
+// HolidaysCSV is the default exchange holiday list used by the market calendar.
+// Update it from the exchange's annual holiday circular.
+//
+//go:embed holidays.csv
+var HolidaysCSV []byte
@@ -2,31 +2,57 @@ package database
 
 import (
 	"context"
-	"trading-platform-backend/models"
+	"fmt"
+	"trading-platform-backend/tracing"
 
 	"github.com/go-redis/redis/v8"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
+	gormtracing "gorm.io/plugin/opentelemetry/tracing"
 )
 
 // Initialize PostgreSQL database
 func Initialize(databaseURL string) (*gorm.DB, error) {
-	db, err := gorm.Open(postgres.Open(databaseURL), &gorm.Config{})
+	db, err := gorm.Open(postgres.Open(databaseURL), &gorm.Config{
+		// Surface unique violations as gorm.ErrDuplicatedKey
+		TranslateError: true,
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	// Auto migrate only User model (no RefreshToken table needed for stateless JWT)
-	err = db.AutoMigrate(
-		&models.User{},
-	)
-	if err != nil {
+	// Trace every query as a child span of the request (query variables are
+	// omitted so password hashes and tokens never reach the trace backend)
+	if err := db.Use(gormtracing.NewPlugin(gormtracing.WithoutMetrics(), gormtracing.WithoutQueryVariables())); err != nil {
 		return nil, err
 	}
 
 	return db, nil
 }
 
+// EnsureSchema brings the schema up to date when autoMigrate is enabled.
+// Otherwise it refuses to run against a database with pending migrations,
+// which must then be applied with the migrate command.
+func EnsureSchema(ctx context.Context, db *gorm.DB, autoMigrate bool) error {
+	migrator, err := NewMigrator(db)
+	if err != nil {
+		return err
+	}
+
+	if autoMigrate {
+		return migrator.Up(ctx)
+	}
+
+	pending, err := migrator.Pending(ctx)
+	if err != nil {
+		return err
+	}
+	if pending > 0 {
+		return fmt.Errorf("%d pending migration(s); run the migrate up command before starting the server", pending)
+	}
+	return nil
+}
+
 // Initialize Redis client
 func InitializeRedis(redisURL, password string) (*redis.Client, error) {
 	opt, err := redis.ParseURL(redisURL)
@@ -39,6 +65,7 @@ func InitializeRedis(redisURL, password string) (*redis.Client, error) {
 	}
 
 	client := redis.NewClient(opt)
+	client.AddHook(tracing.NewRedisHook())
 
 	// Test connection
 	_, err = client.Ping(context.Background()).Result()
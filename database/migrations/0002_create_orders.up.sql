@@ -0,0 +1,13 @@
+CREATE TABLE orders (
+    id            TEXT PRIMARY KEY,
+    user_id       BIGINT NOT NULL REFERENCES users (id),
+    symbol        TEXT NOT NULL,
+    order_type    TEXT NOT NULL,
+    quantity      INTEGER NOT NULL,
+    price         DOUBLE PRECISION NOT NULL DEFAULT 0,
+    status        TEXT NOT NULL,
+    order_time    TIMESTAMPTZ NOT NULL,
+    executed_time TIMESTAMPTZ
+);
+
+CREATE INDEX idx_orders_user_id ON orders (user_id, order_time DESC);
@@ -0,0 +1,16 @@
+CREATE TABLE instruments (
+    id              BIGSERIAL PRIMARY KEY,
+    symbol          TEXT NOT NULL,
+    exchange        TEXT NOT NULL,
+    isin            TEXT NOT NULL DEFAULT '',
+    name            TEXT NOT NULL DEFAULT '',
+    instrument_type TEXT NOT NULL,
+    tick_size       DOUBLE PRECISION NOT NULL,
+    lot_size        INTEGER NOT NULL,
+    trading_status  TEXT NOT NULL,
+    prev_close      DOUBLE PRECISION NOT NULL DEFAULT 0,
+    created_at      TIMESTAMPTZ,
+    updated_at      TIMESTAMPTZ
+);
+
+CREATE UNIQUE INDEX idx_instruments_symbol ON instruments (symbol);
@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS idx_orders_symbol_status ON orders (symbol, status);
@@ -0,0 +1,13 @@
+CREATE TABLE candles (
+    id        BIGSERIAL PRIMARY KEY,
+    symbol    TEXT NOT NULL,
+    interval  TEXT NOT NULL,
+    open_time TIMESTAMPTZ NOT NULL,
+    open      DOUBLE PRECISION NOT NULL,
+    high      DOUBLE PRECISION NOT NULL,
+    low       DOUBLE PRECISION NOT NULL,
+    close     DOUBLE PRECISION NOT NULL,
+    volume    BIGINT NOT NULL DEFAULT 0
+);
+
+CREATE UNIQUE INDEX idx_candles_symbol_interval_open_time ON candles (symbol, interval, open_time);
@@ -0,0 +1 @@
+ALTER TABLE instruments DROP COLUMN IF EXISTS price_band;
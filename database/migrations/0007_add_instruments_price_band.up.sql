@@ -0,0 +1 @@
+ALTER TABLE instruments ADD COLUMN price_band DOUBLE PRECISION NOT NULL DEFAULT 20;
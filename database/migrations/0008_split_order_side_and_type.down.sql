@@ -0,0 +1,5 @@
+UPDATE orders SET order_type = side;
+ALTER TABLE orders DROP COLUMN IF EXISTS side;
+ALTER TABLE orders DROP COLUMN IF EXISTS trigger_price;
+ALTER TABLE orders DROP COLUMN IF EXISTS filled_quantity;
+ALTER TABLE orders DROP COLUMN IF EXISTS average_price;
@@ -0,0 +1,9 @@
+-- order_type used to hold the side; every existing order was a limit order
+ALTER TABLE orders ADD COLUMN side TEXT;
+UPDATE orders SET side = order_type, order_type = 'LIMIT';
+ALTER TABLE orders ALTER COLUMN side SET NOT NULL;
+
+ALTER TABLE orders ADD COLUMN trigger_price DOUBLE PRECISION NOT NULL DEFAULT 0;
+ALTER TABLE orders ADD COLUMN filled_quantity INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE orders ADD COLUMN average_price DOUBLE PRECISION NOT NULL DEFAULT 0;
+UPDATE orders SET filled_quantity = quantity, average_price = price WHERE status = 'COMPLETED';
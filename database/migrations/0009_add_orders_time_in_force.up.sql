@@ -0,0 +1,5 @@
+ALTER TABLE orders ADD COLUMN time_in_force TEXT NOT NULL DEFAULT 'DAY';
+ALTER TABLE orders ADD COLUMN expires_at TIMESTAMPTZ;
+
+CREATE INDEX idx_orders_open_expires_at ON orders (expires_at)
+    WHERE status IN ('PENDING', 'TRIGGER_PENDING');
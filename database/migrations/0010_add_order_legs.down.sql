@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_orders_parent_id;
+ALTER TABLE orders DROP COLUMN IF EXISTS parent_id;
+ALTER TABLE orders DROP COLUMN IF EXISTS leg;
+ALTER TABLE orders DROP COLUMN IF EXISTS order_class;
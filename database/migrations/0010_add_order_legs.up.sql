@@ -0,0 +1,5 @@
+ALTER TABLE orders ADD COLUMN order_class TEXT NOT NULL DEFAULT 'REGULAR';
+ALTER TABLE orders ADD COLUMN leg TEXT NOT NULL DEFAULT '';
+ALTER TABLE orders ADD COLUMN parent_id TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX idx_orders_parent_id ON orders (parent_id) WHERE parent_id <> '';
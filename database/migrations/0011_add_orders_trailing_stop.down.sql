@@ -0,0 +1,2 @@
+ALTER TABLE orders DROP COLUMN IF EXISTS trail_percent;
+ALTER TABLE orders DROP COLUMN IF EXISTS trail_amount;
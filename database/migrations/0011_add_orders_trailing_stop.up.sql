@@ -0,0 +1,2 @@
+ALTER TABLE orders ADD COLUMN trail_amount DOUBLE PRECISION NOT NULL DEFAULT 0;
+ALTER TABLE orders ADD COLUMN trail_percent DOUBLE PRECISION NOT NULL DEFAULT 0;
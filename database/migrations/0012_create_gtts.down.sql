@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS gtt_events;
+DROP TABLE IF EXISTS gtts;
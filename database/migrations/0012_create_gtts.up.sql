@@ -0,0 +1,30 @@
+CREATE TABLE gtts (
+    id           TEXT PRIMARY KEY,
+    user_id      BIGINT NOT NULL REFERENCES users (id),
+    type         TEXT NOT NULL,
+    symbol       TEXT NOT NULL,
+    side         TEXT NOT NULL,
+    quantity     INTEGER NOT NULL,
+    legs         JSONB NOT NULL,
+    status       TEXT NOT NULL,
+    order_id     TEXT NOT NULL DEFAULT '',
+    created_at   TIMESTAMPTZ NOT NULL,
+    updated_at   TIMESTAMPTZ NOT NULL,
+    expires_at   TIMESTAMPTZ NOT NULL,
+    triggered_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_gtts_user_id ON gtts (user_id, created_at DESC);
+CREATE INDEX idx_gtts_active_symbol ON gtts (symbol) WHERE status = 'ACTIVE';
+
+CREATE TABLE gtt_events (
+    id         BIGSERIAL PRIMARY KEY,
+    gtt_id     TEXT NOT NULL REFERENCES gtts (id) ON DELETE CASCADE,
+    event      TEXT NOT NULL,
+    last_price DOUBLE PRECISION NOT NULL DEFAULT 0,
+    order_id   TEXT NOT NULL DEFAULT '',
+    message    TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX idx_gtt_events_gtt_id ON gtt_events (gtt_id, created_at);
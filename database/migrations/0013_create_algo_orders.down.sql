@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_orders_algo_id;
+ALTER TABLE orders DROP COLUMN IF EXISTS algo_id;
+DROP TABLE IF EXISTS algo_orders;
@@ -0,0 +1,25 @@
+CREATE TABLE algo_orders (
+    id               TEXT PRIMARY KEY,
+    user_id          BIGINT NOT NULL REFERENCES users (id),
+    strategy         TEXT NOT NULL,
+    symbol           TEXT NOT NULL,
+    side             TEXT NOT NULL,
+    quantity         INTEGER NOT NULL,
+    price            DOUBLE PRECISION NOT NULL DEFAULT 0,
+    display_quantity INTEGER NOT NULL DEFAULT 0,
+    slices           INTEGER NOT NULL DEFAULT 0,
+    start_time       TIMESTAMPTZ NOT NULL,
+    end_time         TIMESTAMPTZ NOT NULL,
+    filled_quantity  INTEGER NOT NULL DEFAULT 0,
+    average_price    DOUBLE PRECISION NOT NULL DEFAULT 0,
+    status           TEXT NOT NULL,
+    message          TEXT NOT NULL DEFAULT '',
+    created_at       TIMESTAMPTZ NOT NULL,
+    updated_at       TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX idx_algo_orders_user_id ON algo_orders (user_id, created_at DESC);
+CREATE INDEX idx_algo_orders_active ON algo_orders (created_at) WHERE status = 'ACTIVE';
+
+ALTER TABLE orders ADD COLUMN algo_id TEXT NOT NULL DEFAULT '';
+CREATE INDEX idx_orders_algo_id ON orders (algo_id) WHERE algo_id <> '';
@@ -0,0 +1,2 @@
+ALTER TABLE instruments DROP COLUMN IF EXISTS elm_margin;
+ALTER TABLE instruments DROP COLUMN IF EXISTS var_margin;
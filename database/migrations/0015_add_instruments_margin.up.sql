@@ -0,0 +1,2 @@
+ALTER TABLE instruments ADD COLUMN var_margin DOUBLE PRECISION NOT NULL DEFAULT 0;
+ALTER TABLE instruments ADD COLUMN elm_margin DOUBLE PRECISION NOT NULL DEFAULT 0;
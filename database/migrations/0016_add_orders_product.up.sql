@@ -0,0 +1 @@
+ALTER TABLE orders ADD COLUMN product TEXT NOT NULL DEFAULT 'DELIVERY';
@@ -0,0 +1,127 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"trading-platform-backend/logger"
+	"trading-platform-backend/models"
+	"trading-platform-backend/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AlgoHandler struct {
+	algoService *services.AlgoService
+}
+
+func NewAlgoHandler(algoService *services.AlgoService) *AlgoHandler {
+	return &AlgoHandler{
+		algoService: algoService,
+	}
+}
+
+// POST /algos
+func (h *AlgoHandler) CreateAlgoOrder(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	var req models.AlgoOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	algo, err := h.algoService.Create(c.Request.Context(), userID.(uint), req)
+	if err != nil {
+		respondAlgoError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, algo)
+}
+
+// GET /algos
+func (h *AlgoHandler) ListAlgoOrders(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	algos, err := h.algoService.List(c.Request.Context(), userID.(uint))
+	if err != nil {
+		respondAlgoError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.AlgoOrdersResponse{AlgoOrders: algos})
+}
+
+// GET /algos/:id
+func (h *AlgoHandler) GetAlgoOrder(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	algo, err := h.algoService.Get(c.Request.Context(), userID.(uint), c.Param("id"))
+	if err != nil {
+		respondAlgoError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, algo)
+}
+
+// DELETE /algos/:id
+func (h *AlgoHandler) CancelAlgoOrder(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	algo, err := h.algoService.Cancel(c.Request.Context(), userID.(uint), c.Param("id"))
+	if err != nil {
+		respondAlgoError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, algo)
+}
+
+// respondAlgoError maps algo service errors to HTTP responses
+func respondAlgoError(c *gin.Context, err error) {
+	var orderErr *services.OrderError
+	switch {
+	case errors.As(err, &orderErr):
+		c.JSON(http.StatusUnprocessableEntity, models.ErrorResponse{
+			Error:   "Algo order rejected",
+			Code:    orderErr.Code,
+			Message: orderErr.Message,
+		})
+	case errors.Is(err, services.ErrAlgoNotFound):
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Error:   "Algo order not found",
+			Message: err.Error(),
+		})
+	case errors.Is(err, services.ErrAlgoNotActive):
+		c.JSON(http.StatusConflict, models.ErrorResponse{
+			Error:   "Algo order is not active",
+			Message: err.Error(),
+		})
+	default:
+		logger.FromContext(c.Request.Context()).Error("algo order request failed", "error", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "Algo order request failed",
+			Message: "Please try again later",
+		})
+	}
+}
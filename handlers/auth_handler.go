@@ -1,7 +1,9 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
+	"trading-platform-backend/metrics"
 	"trading-platform-backend/models"
 	"trading-platform-backend/services"
 
@@ -29,14 +31,16 @@ func (h *AuthHandler) Signup(c *gin.Context) {
 		return
 	}
 
-	response, err := h.authService.Signup(req)
+	response, err := h.authService.Signup(c.Request.Context(), req)
 	if err != nil {
+		metrics.SignupsTotal.WithLabelValues("failure").Inc()
 		c.JSON(http.StatusBadRequest, models.ErrorResponse{
 			Error:   "Signup failed",
 			Message: err.Error(),
 		})
 		return
 	}
+	metrics.SignupsTotal.WithLabelValues("success").Inc()
 
 	c.JSON(http.StatusOK, response)
 }
@@ -52,21 +56,32 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		return
 	}
 
-	response, err := h.authService.Login(req)
+	response, err := h.authService.Login(c.Request.Context(), req)
 	if err != nil {
 		if err.Error() == "invalid credentials" {
+			metrics.LoginAttemptsTotal.WithLabelValues("invalid_credentials").Inc()
 			c.JSON(http.StatusBadRequest, models.ErrorResponse{
 				Error:   "Invalid credentials",
 				Message: "Email or password is incorrect",
 			})
 			return
 		}
+		if errors.Is(err, services.ErrAccountDisabled) {
+			metrics.LoginAttemptsTotal.WithLabelValues("disabled").Inc()
+			c.JSON(http.StatusForbidden, models.ErrorResponse{
+				Error:   "Account disabled",
+				Message: "Please contact support",
+			})
+			return
+		}
+		metrics.LoginAttemptsTotal.WithLabelValues("error").Inc()
 		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
 			Error:   "Login failed",
 			Message: "Please try again later",
 		})
 		return
 	}
+	metrics.LoginAttemptsTotal.WithLabelValues("success").Inc()
 
 	c.JSON(http.StatusOK, response)
 }
@@ -85,14 +100,16 @@ func (h *AuthHandler) RefreshToken(c *gin.Context) {
 		return
 	}
 
-	response, err := h.authService.RefreshToken(req.RefreshToken)
+	response, err := h.authService.RefreshToken(c.Request.Context(), req.RefreshToken)
 	if err != nil {
+		metrics.TokenRefreshesTotal.WithLabelValues("failure").Inc()
 		c.JSON(http.StatusUnauthorized, models.ErrorResponse{
 			Error:   "Token refresh failed",
 			Message: err.Error(),
 		})
 		return
 	}
+	metrics.TokenRefreshesTotal.WithLabelValues("success").Inc()
 
 	c.JSON(http.StatusOK, response)
 }
@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+	"trading-platform-backend/logger"
+	"trading-platform-backend/models"
+	"trading-platform-backend/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// defaultCandleCount is how many bars are returned when from is omitted
+const defaultCandleCount = 300
+
+type CandleHandler struct {
+	candleService *services.CandleService
+	calendar      *services.MarketCalendar
+}
+
+func NewCandleHandler(candleService *services.CandleService, calendar *services.MarketCalendar) *CandleHandler {
+	return &CandleHandler{
+		candleService: candleService,
+		calendar:      calendar,
+	}
+}
+
+// GET /candles/:symbol?interval=5m&from=<time>&to=<time>
+// Times are RFC 3339 or Unix seconds; to defaults to the market clock's now,
+// including the bar in progress, and from to 300 bars earlier.
+func (h *CandleHandler) GetCandles(c *gin.Context) {
+	intervalName := c.DefaultQuery("interval", "1m")
+	interval, ok := services.LookupCandleInterval(intervalName)
+	if !ok {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid request",
+			Message: "interval must be one of 1m, 5m, 15m, 1h, 1d",
+		})
+		return
+	}
+
+	to, err := parseTimeParam(c.Query("to"), h.calendar.Now().Add(interval.Duration))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "Invalid request", Message: "to: " + err.Error()})
+		return
+	}
+	from, err := parseTimeParam(c.Query("from"), to.Add(-defaultCandleCount*interval.Duration))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "Invalid request", Message: "from: " + err.Error()})
+		return
+	}
+	if !from.Before(to) {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "Invalid request", Message: "from must be before to"})
+		return
+	}
+
+	resp, err := h.candleService.GetCandles(c.Request.Context(), c.Param("symbol"), interval.Name, from, to)
+	switch {
+	case errors.Is(err, services.ErrUnknownSymbol):
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Error:   "Instrument not found",
+			Code:    services.RejectUnknownSymbol,
+			Message: err.Error(),
+		})
+		return
+	case errors.Is(err, services.ErrCandleRangeTooLarge):
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "Invalid request", Message: err.Error()})
+		return
+	case err != nil:
+		logger.FromContext(c.Request.Context()).Error("candle query failed", "error", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "Candles unavailable",
+			Message: "Please try again later",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// parseTimeParam accepts RFC 3339 timestamps or Unix seconds
+func parseTimeParam(raw string, fallback time.Time) (time.Time, error) {
+	if raw == "" {
+		return fallback, nil
+	}
+	if seconds, err := strconv.ParseInt(raw, 10, 64); err == nil {
+		return time.Unix(seconds, 0).UTC(), nil
+	}
+	t, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		return time.Time{}, errors.New("expected RFC 3339 time or Unix seconds")
+	}
+	return t, nil
+}
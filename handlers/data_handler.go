@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"net/http"
+	"trading-platform-backend/models"
 	"trading-platform-backend/services"
 
 	"github.com/gin-gonic/gin"
@@ -25,7 +26,14 @@ func (h *DataHandler) GetHoldings(c *gin.Context) {
 		return
 	}
 
-	holdings := h.dataService.GetHoldings(userID.(uint))
+	holdings, err := h.dataService.GetHoldings(c.Request.Context(), userID.(uint))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "Failed to load holdings",
+			Message: "Please try again later",
+		})
+		return
+	}
 	c.JSON(http.StatusOK, holdings)
 }
 
@@ -37,7 +45,14 @@ func (h *DataHandler) GetOrderbook(c *gin.Context) {
 		return
 	}
 
-	orderbook := h.dataService.GetOrderbook(userID.(uint))
+	orderbook, err := h.dataService.GetOrderbook(c.Request.Context(), userID.(uint))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "Failed to load orderbook",
+			Message: "Please try again later",
+		})
+		return
+	}
 	c.JSON(http.StatusOK, orderbook)
 }
 
@@ -49,6 +64,13 @@ func (h *DataHandler) GetPositions(c *gin.Context) {
 		return
 	}
 
-	positions := h.dataService.GetPositions(userID.(uint))
+	positions, err := h.dataService.GetPositions(c.Request.Context(), userID.(uint))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "Failed to load positions",
+			Message: "Please try again later",
+		})
+		return
+	}
 	c.JSON(http.StatusOK, positions)
 }
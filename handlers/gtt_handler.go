@@ -0,0 +1,153 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"trading-platform-backend/logger"
+	"trading-platform-backend/models"
+	"trading-platform-backend/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type GTTHandler struct {
+	gttService *services.GTTService
+}
+
+func NewGTTHandler(gttService *services.GTTService) *GTTHandler {
+	return &GTTHandler{
+		gttService: gttService,
+	}
+}
+
+// POST /gtt
+func (h *GTTHandler) CreateGTT(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	var req models.GTTRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	gtt, err := h.gttService.Create(c.Request.Context(), userID.(uint), req)
+	if err != nil {
+		respondGTTError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gtt)
+}
+
+// GET /gtt
+func (h *GTTHandler) ListGTTs(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	gtts, err := h.gttService.List(c.Request.Context(), userID.(uint))
+	if err != nil {
+		respondGTTError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.GTTsResponse{GTTs: gtts})
+}
+
+// GET /gtt/:id
+func (h *GTTHandler) GetGTT(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	gtt, err := h.gttService.Get(c.Request.Context(), userID.(uint), c.Param("id"))
+	if err != nil {
+		respondGTTError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gtt)
+}
+
+// PUT /gtt/:id
+func (h *GTTHandler) ModifyGTT(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	var req models.GTTRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	gtt, err := h.gttService.Modify(c.Request.Context(), userID.(uint), c.Param("id"), req)
+	if err != nil {
+		respondGTTError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gtt)
+}
+
+// DELETE /gtt/:id
+func (h *GTTHandler) CancelGTT(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	gtt, err := h.gttService.Cancel(c.Request.Context(), userID.(uint), c.Param("id"))
+	if err != nil {
+		respondGTTError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gtt)
+}
+
+// respondGTTError maps GTT service errors to HTTP responses
+func respondGTTError(c *gin.Context, err error) {
+	var orderErr *services.OrderError
+	switch {
+	case errors.As(err, &orderErr):
+		c.JSON(http.StatusUnprocessableEntity, models.ErrorResponse{
+			Error:   "GTT rejected",
+			Code:    orderErr.Code,
+			Message: orderErr.Message,
+		})
+	case errors.Is(err, services.ErrGTTNotFound):
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Error:   "GTT not found",
+			Message: err.Error(),
+		})
+	case errors.Is(err, services.ErrGTTNotActive):
+		c.JSON(http.StatusConflict, models.ErrorResponse{
+			Error:   "GTT is not active",
+			Message: err.Error(),
+		})
+	default:
+		logger.FromContext(c.Request.Context()).Error("GTT request failed", "error", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "GTT request failed",
+			Message: "Please try again later",
+		})
+	}
+}
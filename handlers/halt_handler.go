@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"trading-platform-backend/logger"
+	"trading-platform-backend/models"
+	"trading-platform-backend/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type HaltHandler struct {
+	haltService *services.HaltService
+}
+
+func NewHaltHandler(haltService *services.HaltService) *HaltHandler {
+	return &HaltHandler{
+		haltService: haltService,
+	}
+}
+
+// GET /admin/halts
+func (h *HaltHandler) ListHalts(c *gin.Context) {
+	halts, err := h.haltService.List(c.Request.Context())
+	if err != nil {
+		respondHaltError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.HaltsResponse{Halts: halts})
+}
+
+// POST /admin/halts/platform
+func (h *HaltHandler) HaltPlatform(c *gin.Context) {
+	h.halt(c, models.HaltScopePlatform, "")
+}
+
+// POST /admin/halts/users/:id
+func (h *HaltHandler) HaltUser(c *gin.Context) {
+	h.halt(c, models.HaltScopeUser, c.Param("id"))
+}
+
+// POST /admin/halts/symbols/:symbol
+func (h *HaltHandler) HaltSymbol(c *gin.Context) {
+	h.halt(c, models.HaltScopeSymbol, c.Param("symbol"))
+}
+
+// DELETE /admin/halts/platform
+func (h *HaltHandler) ResumePlatform(c *gin.Context) {
+	h.resume(c, models.HaltScopePlatform, "")
+}
+
+// DELETE /admin/halts/users/:id
+func (h *HaltHandler) ResumeUser(c *gin.Context) {
+	h.resume(c, models.HaltScopeUser, c.Param("id"))
+}
+
+// DELETE /admin/halts/symbols/:symbol
+func (h *HaltHandler) ResumeSymbol(c *gin.Context) {
+	h.resume(c, models.HaltScopeSymbol, c.Param("symbol"))
+}
+
+func (h *HaltHandler) halt(c *gin.Context, scope, target string) {
+	var req models.HaltRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	halt, err := h.haltService.Halt(c.Request.Context(), models.TradingHalt{
+		Scope:    scope,
+		Target:   target,
+		Reason:   req.Reason,
+		HaltedBy: c.GetString("user_email"),
+	}, req.CancelOpenOrders)
+	if err != nil {
+		respondHaltError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, halt)
+}
+
+func (h *HaltHandler) resume(c *gin.Context, scope, target string) {
+	if err := h.haltService.Resume(c.Request.Context(), scope, target); err != nil {
+		respondHaltError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse{Message: "Trading resumed"})
+}
+
+// respondHaltError maps halt service errors to HTTP responses
+func respondHaltError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrHaltNotFound):
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Error:   "Trading halt not found",
+			Message: err.Error(),
+		})
+	case errors.Is(err, services.ErrHaltTargetNotFound):
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Error:   "Unknown user or symbol",
+			Message: err.Error(),
+		})
+	default:
+		logger.FromContext(c.Request.Context()).Error("Trading halt request failed", "error", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "Trading halt request failed",
+			Message: "Please try again later",
+		})
+	}
+}
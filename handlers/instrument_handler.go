@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"trading-platform-backend/models"
+	"trading-platform-backend/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultInstrumentLimit = 50
+	maxInstrumentLimit     = 500
+)
+
+type InstrumentHandler struct {
+	instrumentService *services.InstrumentService
+}
+
+func NewInstrumentHandler(instrumentService *services.InstrumentService) *InstrumentHandler {
+	return &InstrumentHandler{
+		instrumentService: instrumentService,
+	}
+}
+
+// GET /instruments?q=<symbol or name>&limit=<n>
+func (h *InstrumentHandler) ListInstruments(c *gin.Context) {
+	limit := defaultInstrumentLimit
+	if raw := c.Query("limit"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 1 || n > maxInstrumentLimit {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Error:   "Invalid request",
+				Message: "limit must be between 1 and 500",
+			})
+			return
+		}
+		limit = n
+	}
+
+	instruments := h.instrumentService.Search(c.Query("q"), limit)
+	if instruments == nil {
+		instruments = []models.Instrument{}
+	}
+	c.JSON(http.StatusOK, models.InstrumentsResponse{Instruments: instruments})
+}
+
+// GET /instruments/:symbol
+func (h *InstrumentHandler) GetInstrument(c *gin.Context) {
+	instrument, ok := h.instrumentService.Get(c.Param("symbol"))
+	if !ok {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Error:   "Instrument not found",
+			Code:    services.RejectUnknownSymbol,
+			Message: "No instrument with symbol " + c.Param("symbol"),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, instrument)
+}
@@ -0,0 +1,40 @@
+package handlers
+
+import (
+	"net/http"
+	"trading-platform-backend/logger"
+	"trading-platform-backend/models"
+	"trading-platform-backend/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type MarginHandler struct {
+	marginService *services.MarginService
+}
+
+func NewMarginHandler(marginService *services.MarginService) *MarginHandler {
+	return &MarginHandler{
+		marginService: marginService,
+	}
+}
+
+// GET /margins
+func (h *MarginHandler) GetMargins(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	margins, err := h.marginService.Margins(c.Request.Context(), userID.(uint))
+	if err != nil {
+		logger.FromContext(c.Request.Context()).Error("Margin calculation failed", "error", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "Failed to load margins",
+			Message: "Please try again later",
+		})
+		return
+	}
+	c.JSON(http.StatusOK, margins)
+}
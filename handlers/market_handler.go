@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"trading-platform-backend/logger"
+	"trading-platform-backend/models"
+	"trading-platform-backend/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxQuoteSymbols caps the symbols accepted by a single quotes request
+const maxQuoteSymbols = 50
+
+type MarketHandler struct {
+	marketDataService *services.MarketDataService
+	calendar          *services.MarketCalendar
+}
+
+func NewMarketHandler(marketDataService *services.MarketDataService, calendar *services.MarketCalendar) *MarketHandler {
+	return &MarketHandler{
+		marketDataService: marketDataService,
+		calendar:          calendar,
+	}
+}
+
+// GET /market/status
+func (h *MarketHandler) GetStatus(c *gin.Context) {
+	c.JSON(http.StatusOK, h.calendar.Status())
+}
+
+// GET /quotes?symbols=TCS,INFY
+func (h *MarketHandler) GetQuotes(c *gin.Context) {
+	var symbols []string
+	for _, symbol := range strings.Split(c.Query("symbols"), ",") {
+		if symbol = strings.TrimSpace(symbol); symbol != "" {
+			symbols = append(symbols, symbol)
+		}
+	}
+	if len(symbols) == 0 || len(symbols) > maxQuoteSymbols {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid request",
+			Message: "symbols must list between 1 and 50 comma-separated symbols",
+		})
+		return
+	}
+
+	quotes, err := h.marketDataService.GetQuotes(c.Request.Context(), symbols)
+	if err != nil {
+		respondMarketDataError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, models.QuotesResponse{Quotes: quotes})
+}
+
+// GET /depth/:symbol?levels=<n>
+func (h *MarketHandler) GetDepth(c *gin.Context) {
+	levels := services.DefaultDepthLevels
+	if raw := c.Query("levels"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 1 || n > services.MaxDepthLevels {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Error:   "Invalid request",
+				Message: "levels must be between 1 and " + strconv.Itoa(services.MaxDepthLevels),
+			})
+			return
+		}
+		levels = n
+	}
+
+	depth, err := h.marketDataService.GetDepth(c.Request.Context(), c.Param("symbol"), levels)
+	if err != nil {
+		respondMarketDataError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, depth)
+}
+
+func respondMarketDataError(c *gin.Context, err error) {
+	if errors.Is(err, services.ErrUnknownSymbol) {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Error:   "Instrument not found",
+			Code:    services.RejectUnknownSymbol,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	logger.FromContext(c.Request.Context()).Error("market data request failed", "error", err)
+	c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+		Error:   "Market data unavailable",
+		Message: "Please try again later",
+	})
+}
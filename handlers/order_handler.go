@@ -0,0 +1,162 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"trading-platform-backend/logger"
+	"trading-platform-backend/models"
+	"trading-platform-backend/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type OrderHandler struct {
+	orderService *services.OrderService
+}
+
+func NewOrderHandler(orderService *services.OrderService) *OrderHandler {
+	return &OrderHandler{
+		orderService: orderService,
+	}
+}
+
+// POST /orders
+func (h *OrderHandler) PlaceOrder(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	var req models.PlaceOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	order, err := h.orderService.PlaceOrder(c.Request.Context(), userID.(uint), req)
+	if err != nil {
+		respondOrderError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, order)
+}
+
+// POST /orders/bracket
+func (h *OrderHandler) PlaceBracketOrder(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	var req models.BracketOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	order, err := h.orderService.PlaceBracketOrder(c.Request.Context(), userID.(uint), req)
+	if err != nil {
+		respondOrderError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, order)
+}
+
+// POST /orders/oco
+func (h *OrderHandler) PlaceOCOOrder(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	var req models.OCOOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	order, err := h.orderService.PlaceOCOOrder(c.Request.Context(), userID.(uint), req)
+	if err != nil {
+		respondOrderError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, order)
+}
+
+// DELETE /orders/:id
+func (h *OrderHandler) CancelOrder(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	order, err := h.orderService.CancelOrder(c.Request.Context(), userID.(uint), c.Param("id"))
+	if err != nil {
+		respondOrderError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, order)
+}
+
+// GET /orders/:id
+func (h *OrderHandler) GetOrder(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	order, err := h.orderService.GetOrder(c.Request.Context(), userID.(uint), c.Param("id"))
+	if err != nil {
+		respondOrderError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, order)
+}
+
+// respondOrderError maps order service errors to HTTP responses
+func respondOrderError(c *gin.Context, err error) {
+	var orderErr *services.OrderError
+	switch {
+	case errors.As(err, &orderErr):
+		c.JSON(http.StatusUnprocessableEntity, models.ErrorResponse{
+			Error:   "Order rejected",
+			Code:    orderErr.Code,
+			Message: orderErr.Message,
+		})
+	case errors.Is(err, services.ErrOrderNotFound):
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Error:   "Order not found",
+			Message: err.Error(),
+		})
+	case errors.Is(err, services.ErrOrderNotCancellable):
+		c.JSON(http.StatusConflict, models.ErrorResponse{
+			Error:   "Order cannot be cancelled",
+			Message: err.Error(),
+		})
+	default:
+		logger.FromContext(c.Request.Context()).Error("order request failed", "error", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "Order request failed",
+			Message: "Please try again later",
+		})
+	}
+}
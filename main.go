@@ -2,76 +2,99 @@
 package main
 
 import (
-	"log"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
 	"os"
 	"trading-platform-backend/config"
 	"trading-platform-backend/database"
-	"trading-platform-backend/middleware"
-	"trading-platform-backend/routes"
-	"trading-platform-backend/services"
+	"trading-platform-backend/logger"
 
-	"github.com/gin-contrib/cors"
-	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
+	"gorm.io/gorm"
 )
 
-func main() {
-	// Load environment variables
-	if err := godotenv.Load(); err != nil {
-		log.Println("No .env file found")
-	}
+const usage = `Usage: trading-platform-backend <command> [arguments]
 
-	// Initialize configuration
-	cfg := config.Load()
+Commands:
+  serve                         start the HTTP server (default)
+  migrate <up|down|status|to>   manage database schema migrations
+  seed                          load instruments, demo users and orders
+  user create|disable|enable|reset-password
+                                manage user accounts
+  token inspect <token>         decode and verify a JWT with the configured secrets
+  candles import|prune          backfill or prune OHLCV candles
 
-	// Initialize database
-	db, err := database.Initialize(cfg.DatabaseURL)
-	if err != nil {
-		log.Fatal("Failed to connect to database:", err)
-	}
+Run "trading-platform-backend <command> -h" for command options.`
 
-	// Initialize Redis
-	redisClient, err := database.InitializeRedis(cfg.RedisURL, cfg.RedisPassword)
-	if err != nil {
-		log.Fatal("Failed to connect to Redis:", err)
+func main() {
+	command, args := "serve", []string{}
+	if len(os.Args) > 1 {
+		command, args = os.Args[1], os.Args[2:]
 	}
 
-	// Initialize services
-	authService := services.NewAuthService(db, redisClient, cfg)
-	dataService := services.NewDataService()
-	circuitBreakerService := services.NewCircuitBreakerService()
-
-	// Set Gin mode
-	if cfg.Environment == "production" {
-		gin.SetMode(gin.ReleaseMode)
+	switch command {
+	case "serve":
+		serve()
+	case "migrate":
+		runMigrate(args)
+	case "seed":
+		runSeed(args)
+	case "user":
+		runUser(args)
+	case "token":
+		runToken(args)
+	case "candles":
+		runCandles(args)
+	case "help", "-h", "--help":
+		fmt.Println(usage)
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n%s\n", command, usage)
+		os.Exit(2)
 	}
+}
 
-	// Initialize Gin router
-	r := gin.Default()
-
-	// CORS middleware
-	corsConfig := cors.DefaultConfig()
-	corsConfig.AllowAllOrigins = true
-	corsConfig.AllowHeaders = []string{"Origin", "Content-Length", "Content-Type", "Authorization"}
-	corsConfig.AllowMethods = []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"}
-	r.Use(cors.New(corsConfig))
-
-	// Global middleware
-	r.Use(middleware.Logger())
-	r.Use(middleware.Recovery())
-	r.Use(middleware.CircuitBreaker(circuitBreakerService))
+// loadConfig reads .env, the config file and the environment, sets up
+// logging and exits on invalid settings
+func loadConfig() (*config.Config, string) {
+	// Load environment variables
+	envErr := godotenv.Load()
 
-	// Routes
-	routes.SetupRoutes(r, authService, dataService, cfg)
+	// Initialize configuration, refusing to start on invalid settings
+	configPath := os.Getenv("CONFIG_FILE")
+	cfg, err := config.LoadFile(configPath)
+	if err != nil {
+		var validationErr *config.ValidationError
+		if errors.As(err, &validationErr) {
+			slog.Error("Invalid configuration", "problems", validationErr.Problems)
+			os.Exit(1)
+		}
+		fatal("Configuration error", err)
+	}
 
-	// Start server
-	port := os.Getenv("PORT")
-	if port == "" {
-		port = "8080"
+	// Initialize structured logging
+	logger.Setup(cfg.LogLevel, cfg.LogFormat)
+	if envErr != nil {
+		slog.Info("No .env file found")
 	}
+	return cfg, configPath
+}
 
-	log.Printf("Server starting on port %s", port)
-	if err := r.Run(":" + port); err != nil {
-		log.Fatal("Failed to start server:", err)
+// openDatabase connects to PostgreSQL and verifies the schema is current
+func openDatabase(cfg *config.Config) *gorm.DB {
+	db, err := database.Initialize(cfg.DatabaseURL)
+	if err != nil {
+		fatal("Failed to connect to database", err)
+	}
+	if err := database.EnsureSchema(context.Background(), db, cfg.DBAutoMigrate); err != nil {
+		fatal("Database schema is not up to date", err)
 	}
+	return db
+}
+
+// fatal logs an unrecoverable startup error and exits
+func fatal(msg string, err error) {
+	slog.Error(msg, "error", err)
+	os.Exit(1)
 }
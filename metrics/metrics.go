@@ -0,0 +1,105 @@
+package metrics
+
+import (
+	"database/sql"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const namespace = "trading"
+
+// HTTP metrics
+var (
+	HTTPRequestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "requests_total",
+		Help:      "Total number of HTTP requests by route template and status.",
+	}, []string{"method", "route", "status"})
+
+	HTTPRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "HTTP request latency by route template and status.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+)
+
+// Resilience metrics
+var (
+	CircuitBreakerState = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "circuit_breaker",
+		Name:      "state",
+		Help:      "Circuit breaker state (0 = closed, 1 = half-open, 2 = open).",
+	}, []string{"name"})
+
+	RateLimitRejectionsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "rate_limit",
+		Name:      "rejections_total",
+		Help:      "Requests rejected by a rate limiter.",
+	}, []string{"limiter"})
+)
+
+// Auth metrics
+var (
+	LoginAttemptsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "auth",
+		Name:      "login_attempts_total",
+		Help:      "Login attempts by result (success, invalid_credentials, disabled, error).",
+	}, []string{"result"})
+
+	SignupsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "auth",
+		Name:      "signups_total",
+		Help:      "Signup attempts by result (success, failure).",
+	}, []string{"result"})
+
+	TokenRefreshesTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "auth",
+		Name:      "token_refreshes_total",
+		Help:      "Refresh token exchanges by result (success, failure).",
+	}, []string{"result"})
+)
+
+// Order metrics
+var (
+	OrderTransitionsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "orders",
+		Name:      "transitions_total",
+		Help:      "Orders reaching a final status (COMPLETED, CANCELLED, EXPIRED) by status and time in force.",
+	}, []string{"status", "time_in_force"})
+
+	RiskRejectionsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "orders",
+		Name:      "risk_rejections_total",
+		Help:      "Orders rejected by a pre-trade risk check, by reason code.",
+	}, []string{"code"})
+
+	LiquidationsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "orders",
+		Name:      "liquidations_total",
+		Help:      "Accounts closed out by the risk job, by reason (margin_call, square_off).",
+	}, []string{"reason"})
+)
+
+// RegisterDBStats exposes connection pool statistics of the SQL database
+func RegisterDBStats(db *sql.DB, name string) {
+	prometheus.MustRegister(collectors.NewDBStatsCollector(db, name))
+}
+
+// RegisterRedisPoolStats exposes connection pool statistics of the Redis client
+func RegisterRedisPoolStats(client *redis.Client) {
+	prometheus.MustRegister(newRedisPoolCollector(client))
+}
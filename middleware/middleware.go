@@ -1,39 +1,151 @@
 package middleware
 
 import (
-	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"io"
+	"log/slog"
 	"net/http"
+	"runtime/debug"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
+	"trading-platform-backend/config"
+	"trading-platform-backend/logger"
+	"trading-platform-backend/metrics"
 	"trading-platform-backend/models"
+	"trading-platform-backend/repository"
 	"trading-platform-backend/services"
 
+	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
-	"github.com/go-redis/redis/v8"
+	"github.com/sony/gobreaker"
+	"go.opentelemetry.io/otel/trace"
 )
 
-// Logger middleware
+// RequestIDHeader carries the correlation ID between clients, proxies and this service
+const RequestIDHeader = "X-Request-ID"
+
+// RequestID middleware accepts an incoming X-Request-ID or generates one, and
+// attaches it to the Gin context, the response header and the request logger
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(RequestIDHeader)
+		if requestID == "" || len(requestID) > 128 {
+			requestID = newRequestID()
+		}
+
+		c.Set("request_id", requestID)
+		c.Header(RequestIDHeader, requestID)
+
+		reqLogger := slog.Default().With("request_id", requestID)
+		if spanCtx := trace.SpanContextFromContext(c.Request.Context()); spanCtx.IsValid() {
+			reqLogger = reqLogger.With("trace_id", spanCtx.TraceID().String())
+		}
+		c.Request = c.Request.WithContext(logger.WithContext(c.Request.Context(), reqLogger))
+
+		c.Next()
+	}
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return strconv.FormatInt(time.Now().UnixNano(), 36)
+	}
+	return hex.EncodeToString(b)
+}
+
+// Logger middleware writes one structured access log line per request
 func Logger() gin.HandlerFunc {
-	return gin.LoggerWithFormatter(func(param gin.LogFormatterParams) string {
-		return fmt.Sprintf("%s - [%s] \"%s %s %s %d %s \"%s\" %s\"\n",
-			param.ClientIP,
-			param.TimeStamp.Format(time.RFC1123),
-			param.Method,
-			param.Path,
-			param.Request.Proto,
-			param.StatusCode,
-			param.Latency,
-			param.Request.UserAgent(),
-			param.ErrorMessage,
-		)
-	})
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		status := c.Writer.Status()
+		attrs := []any{
+			"method", c.Request.Method,
+			"path", c.Request.URL.Path,
+			"route", c.FullPath(),
+			"status", status,
+			"latency_ms", float64(time.Since(start).Microseconds()) / 1000,
+			"client_ip", c.ClientIP(),
+			"user_agent", c.Request.UserAgent(),
+			"bytes", c.Writer.Size(),
+		}
+		if len(c.Errors) > 0 {
+			attrs = append(attrs, "errors", c.Errors.String())
+		}
+
+		level := slog.LevelInfo
+		switch {
+		case status >= 500:
+			level = slog.LevelError
+		case status >= 400:
+			level = slog.LevelWarn
+		}
+
+		logger.FromContext(c.Request.Context()).Log(c.Request.Context(), level, "http request", attrs...)
+	}
+}
+
+// CORS middleware built from configuration. A "*" origin allows every origin;
+// an empty list rejects all cross-origin requests.
+func CORS(cfg config.CORSConfig) gin.HandlerFunc {
+	corsConfig := cors.Config{
+		AllowMethods:     cfg.AllowedMethods,
+		AllowHeaders:     cfg.AllowedHeaders,
+		ExposeHeaders:    cfg.ExposedHeaders,
+		AllowCredentials: cfg.AllowCredentials,
+		AllowWildcard:    true,
+		MaxAge:           cfg.MaxAge,
+	}
+
+	switch {
+	case len(cfg.AllowedOrigins) == 0:
+		corsConfig.AllowOriginFunc = func(string) bool { return false }
+	case slices.Contains(cfg.AllowedOrigins, "*"):
+		corsConfig.AllowAllOrigins = true
+	default:
+		corsConfig.AllowOrigins = cfg.AllowedOrigins
+	}
+
+	return cors.New(corsConfig)
+}
+
+// Metrics middleware records request count and latency per route template
+func Metrics() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		// Use the route template (e.g. /api/v1/orders/:id) to keep label cardinality bounded
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		status := strconv.Itoa(c.Writer.Status())
+
+		metrics.HTTPRequestsTotal.WithLabelValues(c.Request.Method, route, status).Inc()
+		metrics.HTTPRequestDuration.WithLabelValues(c.Request.Method, route, status).Observe(time.Since(start).Seconds())
+	}
 }
 
 // Recovery middleware
 func Recovery() gin.HandlerFunc {
-	return gin.Recovery()
+	return gin.CustomRecoveryWithWriter(io.Discard, func(c *gin.Context, recovered any) {
+		logger.FromContext(c.Request.Context()).Error("panic recovered",
+			"panic", fmt.Sprint(recovered),
+			"stack", string(debug.Stack()),
+		)
+		c.AbortWithStatusJSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "Internal server error",
+			Message: "Please try again later",
+		})
+	})
 }
 
 // JWT Auth middleware
@@ -74,6 +186,27 @@ func AuthMiddleware(authService *services.AuthService) gin.HandlerFunc {
 		// Set user info in context
 		c.Set("user_id", claims.UserID)
 		c.Set("user_email", claims.Email)
+		c.Set("user_role", claims.Role)
+
+		reqLogger := logger.FromContext(c.Request.Context()).With("user_id", claims.UserID)
+		c.Request = c.Request.WithContext(logger.WithContext(c.Request.Context(), reqLogger))
+
+		c.Next()
+	}
+}
+
+// RequireRole rejects authenticated users whose token does not carry role.
+// It must run after AuthMiddleware.
+func RequireRole(role string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetString("user_role") != role {
+			c.JSON(http.StatusForbidden, models.ErrorResponse{
+				Error:   "Forbidden",
+				Message: fmt.Sprintf("This endpoint requires the %s role", role),
+			})
+			c.Abort()
+			return
+		}
 		c.Next()
 	}
 }
@@ -82,8 +215,10 @@ func AuthMiddleware(authService *services.AuthService) gin.HandlerFunc {
 func CircuitBreaker(cbService *services.CircuitBreakerService) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		breaker := cbService.GetBreaker("api")
+		timeout := cbService.Timeout()
 
 		result, err := breaker.Execute(func() (interface{}, error) {
+			start := time.Now()
 			c.Next()
 
 			// Check if there was an error in the handler
@@ -96,10 +231,17 @@ func CircuitBreaker(cbService *services.CircuitBreakerService) gin.HandlerFunc {
 				return nil, fmt.Errorf("server error: %d", c.Writer.Status())
 			}
 
+			// Slow responses count against the breaker too
+			if elapsed := time.Since(start); elapsed > timeout {
+				return nil, fmt.Errorf("slow response: %s", elapsed)
+			}
+
 			return nil, nil
 		})
 
-		if err != nil {
+		// Requests the handler served have their response already; only
+		// short-circuited ones are answered here
+		if errors.Is(err, gobreaker.ErrOpenState) || errors.Is(err, gobreaker.ErrTooManyRequests) {
 			c.JSON(http.StatusServiceUnavailable, models.ErrorResponse{
 				Error:   "Service temporarily unavailable",
 				Message: "Please try again later",
@@ -112,43 +254,42 @@ func CircuitBreaker(cbService *services.CircuitBreakerService) gin.HandlerFunc {
 	}
 }
 
-// Simple Rate Limit middleware for login route
-func SimpleRateLimit(redisClient *redis.Client) gin.HandlerFunc {
+// Simple Rate Limit middleware for login route. The policy is read on every
+// request so reloaded limits apply immediately.
+func SimpleRateLimit(store repository.RateLimitStore, cfgManager *config.Manager) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		clientIP := c.ClientIP()
-		key := fmt.Sprintf("rate_limit:login:%s", clientIP)
-
-		ctx := context.Background()
+		policy := cfgManager.Current().RateLimitConfig.Login
+		key := fmt.Sprintf("rate_limit:login:%s", c.ClientIP())
+		ctx := c.Request.Context()
 
 		// Get current count
-		val, err := redisClient.Get(ctx, key).Result()
-		if err != nil && err != redis.Nil {
-			// If Redis fails, allow request to continue
+		count, resetIn, err := store.Attempts(ctx, key)
+		if err != nil {
+			// If the store fails, allow request to continue
 			c.Next()
 			return
 		}
 
-		count := 0
-		if val != "" {
-			count, _ = strconv.Atoi(val)
-		}
+		c.Header("X-RateLimit-Limit", strconv.Itoa(policy.MaxRequests))
+		c.Header("X-RateLimit-Remaining", strconv.Itoa(max(policy.MaxRequests-count-1, 0)))
 
-		// Limit: 5 attempts per 15 minutes
-		maxAttempts := 5
-		if count >= maxAttempts {
+		if count >= policy.MaxRequests {
+			metrics.RateLimitRejectionsTotal.WithLabelValues("login").Inc()
+			if resetIn > 0 {
+				c.Header("Retry-After", strconv.Itoa(int(resetIn.Seconds())))
+			}
 			c.JSON(http.StatusTooManyRequests, gin.H{
 				"error":   "Too many login attempts",
-				"message": "Please try again in 15 minutes",
+				"message": fmt.Sprintf("Please try again in %s", policy.Window),
 			})
 			c.Abort()
 			return
 		}
 
 		// Increment counter and set expiry
-		pipe := redisClient.Pipeline()
-		pipe.Incr(ctx, key)
-		pipe.Expire(ctx, key, 15*time.Minute)
-		pipe.Exec(ctx)
+		if err := store.RecordAttempt(ctx, key, policy.Window); err != nil {
+			logger.FromContext(ctx).Warn("failed to record rate limit attempt", "error", err)
+		}
 
 		c.Next()
 	}
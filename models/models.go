@@ -8,14 +8,22 @@ import (
 
 // User represents the user model
 type User struct {
-	ID        uint           `json:"id" gorm:"primaryKey"`
-	Email     string         `json:"email" gorm:"uniqueIndex;not null"`
-	Password  string         `json:"-" gorm:"not null"`
-	CreatedAt time.Time      `json:"created_at"`
-	UpdatedAt time.Time      `json:"updated_at"`
-	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+	ID         uint           `json:"id" gorm:"primaryKey"`
+	Email      string         `json:"email" gorm:"uniqueIndex;not null"`
+	Password   string         `json:"-" gorm:"not null"`
+	Role       string         `json:"role" gorm:"not null;default:trader"` // trader or admin
+	DisabledAt *time.Time     `json:"disabled_at,omitempty"`
+	CreatedAt  time.Time      `json:"created_at"`
+	UpdatedAt  time.Time      `json:"updated_at"`
+	DeletedAt  gorm.DeletedAt `json:"-" gorm:"index"`
 }
 
+// User roles
+const (
+	RoleTrader = "trader"
+	RoleAdmin  = "admin"
+)
+
 // RefreshToken represents refresh token for JWT
 type RefreshToken struct {
 	ID        uint      `json:"id" gorm:"primaryKey"`
@@ -26,6 +34,32 @@ type RefreshToken struct {
 	User      User      `json:"-" gorm:"foreignKey:UserID"`
 }
 
+// Instrument trading statuses
+const (
+	InstrumentActive    = "ACTIVE"
+	InstrumentSuspended = "SUSPENDED"
+	InstrumentHalted    = "HALTED"
+)
+
+// Instrument is an entry in the instrument master (one tradable symbol)
+type Instrument struct {
+	ID             uint      `json:"-" gorm:"primaryKey"`
+	Symbol         string    `json:"symbol" gorm:"uniqueIndex;not null"`
+	Exchange       string    `json:"exchange" gorm:"not null"`
+	ISIN           string    `json:"isin"`
+	Name           string    `json:"name"`
+	InstrumentType string    `json:"instrument_type" gorm:"not null"` // EQ, ETF, FUT, OPT
+	TickSize       float64   `json:"tick_size" gorm:"not null"`
+	LotSize        int       `json:"lot_size" gorm:"not null"`
+	TradingStatus  string    `json:"trading_status" gorm:"not null"` // ACTIVE, SUSPENDED or HALTED
+	PrevClose      float64   `json:"prev_close"`
+	PriceBand      float64   `json:"price_band"`                          // allowed move from previous close in percent; 0 means no band
+	VaRMargin      float64   `json:"var_margin" gorm:"column:var_margin"` // value-at-risk margin in percent of exposure
+	ELMMargin      float64   `json:"elm_margin" gorm:"column:elm_margin"` // extreme loss margin in percent of exposure
+	CreatedAt      time.Time `json:"-"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
 // Holdings represents user's stock holdings
 type Holdings struct {
 	Symbol       string  `json:"symbol"`
@@ -36,16 +70,96 @@ type Holdings struct {
 	PNLPercent   float64 `json:"pnl_percent"`
 }
 
+// Order statuses
+const (
+	OrderStatusPending        = "PENDING"
+	OrderStatusTriggerPending = "TRIGGER_PENDING" // stop order waiting for its trigger price
+	OrderStatusCompleted      = "COMPLETED"
+	OrderStatusCancelled      = "CANCELLED"
+	OrderStatusRejected       = "REJECTED"
+	OrderStatusExpired        = "EXPIRED"
+	OrderStatusWaiting        = "WAITING" // bracket exit leg held until its entry fills
+)
+
+// Order classes
+const (
+	OrderClassRegular = "REGULAR"
+	OrderClassBracket = "BRACKET" // entry with target and stop-loss exit legs
+	OrderClassOCO     = "OCO"     // target and stop-loss pair, one cancels the other
+)
+
+// Order legs of bracket and OCO orders
+const (
+	LegEntry    = "ENTRY"
+	LegTarget   = "TARGET"
+	LegStopLoss = "STOP_LOSS"
+)
+
+// Order sides
+const (
+	SideBuy  = "BUY"
+	SideSell = "SELL"
+)
+
+// Order types
+const (
+	OrderTypeMarket    = "MARKET"
+	OrderTypeLimit     = "LIMIT"
+	OrderTypeStopLimit = "SL"   // becomes a limit order once the trigger price trades
+	OrderTypeStopLoss  = "SL-M" // becomes a market order once the trigger price trades
+	OrderTypeTrailing  = "TSL"  // SL-M whose trigger follows favorable price moves
+)
+
+// IsStopOrderType reports whether orders of the type wait for a trigger price
+func IsStopOrderType(orderType string) bool {
+	return orderType == OrderTypeStopLimit || orderType == OrderTypeStopLoss || orderType == OrderTypeTrailing
+}
+
+// Products decide how long a position may be carried
+const (
+	ProductIntraday = "INTRADAY" // squared off before the market closes
+	ProductDelivery = "DELIVERY" // carried until the user closes it
+)
+
+// Time in force
+const (
+	TimeInForceDay = "DAY" // expires at the close of the trading day
+	TimeInForceIOC = "IOC" // fill what is possible immediately, cancel the rest
+	TimeInForceFOK = "FOK" // fill completely immediately or cancel
+	TimeInForceGTC = "GTC" // stays open until filled or cancelled
+	TimeInForceGTD = "GTD" // expires at the close of its expiry date
+)
+
 // Order represents order data
 type Order struct {
-	ID           string     `json:"id"`
-	Symbol       string     `json:"symbol"`
-	OrderType    string     `json:"order_type"` // BUY or SELL
-	Quantity     int        `json:"quantity"`
-	Price        float64    `json:"price"`
-	Status       string     `json:"status"`
-	OrderTime    time.Time  `json:"order_time"`
-	ExecutedTime *time.Time `json:"executed_time,omitempty"`
+	ID             string     `json:"id" gorm:"primaryKey"`
+	UserID         uint       `json:"-" gorm:"not null;index"`
+	Symbol         string     `json:"symbol" gorm:"not null"`
+	Side           string     `json:"side" gorm:"not null"`       // BUY or SELL
+	OrderType      string     `json:"order_type" gorm:"not null"` // MARKET, LIMIT, SL, SL-M or TSL
+	Quantity       int        `json:"quantity" gorm:"not null"`
+	Price          float64    `json:"price"`                   // limit price; 0 for MARKET and SL-M
+	TriggerPrice   float64    `json:"trigger_price,omitempty"` // for TSL, where the stop currently sits
+	TrailAmount    float64    `json:"trail_amount,omitempty"`  // TSL offset from the best price
+	TrailPercent   float64    `json:"trail_percent,omitempty"` // TSL offset in percent of the best price
+	FilledQuantity int        `json:"filled_quantity"`
+	AveragePrice   float64    `json:"average_price"`
+	TimeInForce    string     `json:"time_in_force" gorm:"not null"`
+	ExpiresAt      *time.Time `json:"expires_at,omitempty"`
+	Status         string     `json:"status" gorm:"not null"`
+	OrderTime      time.Time  `json:"order_time" gorm:"not null"`
+	ExecutedTime   *time.Time `json:"executed_time,omitempty"`
+	OrderClass     string     `json:"order_class" gorm:"not null"` // REGULAR, BRACKET or OCO
+	Leg            string     `json:"leg,omitempty"`               // ENTRY, TARGET or STOP_LOSS
+	ParentID       string     `json:"parent_id,omitempty"`         // set on legs linked to a parent order
+	Legs           []Order    `json:"legs,omitempty" gorm:"-"`     // linked legs, filled in for parent orders
+	AlgoID         string     `json:"algo_id,omitempty"`           // set on slices of an algo order
+	Product        string     `json:"product" gorm:"not null"`     // INTRADAY or DELIVERY
+}
+
+// IsOpen reports whether the order can still execute
+func (o *Order) IsOpen() bool {
+	return o.Status == OrderStatusPending || o.Status == OrderStatusTriggerPending
 }
 
 // Position represents user's positions
@@ -56,9 +170,41 @@ type Position struct {
 	CurrentPrice         float64 `json:"current_price"`
 	UnrealizedPNL        float64 `json:"unrealized_pnl"`
 	UnrealizedPNLPercent float64 `json:"unrealized_pnl_percent"`
+	RealizedPNL          float64 `json:"realized_pnl"`
+	DayPNL               float64 `json:"day_pnl"`       // change since the previous close, including closed trades
 	PositionType         string  `json:"position_type"` // LONG or SHORT
 }
 
+// Margins summarizes a user's account: cash, equity marked to market and
+// the margin that positions and open orders hold
+type Margins struct {
+	Cash              float64        `json:"cash"` // starting cash plus realized P&L
+	UnrealizedPNL     float64        `json:"unrealized_pnl"`
+	Equity            float64        `json:"equity"`         // cash plus unrealized P&L
+	UsedMargin        float64        `json:"used_margin"`    // held against open positions at the last price
+	BlockedMargin     float64        `json:"blocked_margin"` // held for open orders that would add exposure
+	AvailableMargin   float64        `json:"available_margin"`
+	MaintenanceMargin float64        `json:"maintenance_margin"`
+	MarginCall        bool           `json:"margin_call"` // equity is below the maintenance margin
+	Symbols           []SymbolMargin `json:"symbols"`
+}
+
+// SymbolMargin is the margin held for one symbol
+type SymbolMargin struct {
+	Symbol        string  `json:"symbol"`
+	Quantity      int     `json:"quantity"`    // net position, negative when short
+	MarginRate    float64 `json:"margin_rate"` // percent of exposure
+	LastPrice     float64 `json:"last_price"`
+	UsedMargin    float64 `json:"used_margin"`
+	BlockedMargin float64 `json:"blocked_margin"`
+}
+
+// Position types
+const (
+	PositionLong  = "LONG"
+	PositionShort = "SHORT"
+)
+
 // PNLCard represents PNL summary
 type PNLCard struct {
 	TotalPNL        float64 `json:"total_pnl"`
@@ -103,9 +249,297 @@ type PositionsResponse struct {
 
 type ErrorResponse struct {
 	Error   string `json:"error"`
+	Code    string `json:"code,omitempty"` // machine-readable reason, e.g. UNKNOWN_SYMBOL
 	Message string `json:"message,omitempty"`
 }
 
+type PlaceOrderRequest struct {
+	Symbol       string  `json:"symbol" binding:"required"`
+	Side         string  `json:"side" binding:"required,oneof=BUY SELL"`
+	OrderType    string  `json:"order_type" binding:"required,oneof=MARKET LIMIT SL SL-M TSL"`
+	Quantity     int     `json:"quantity" binding:"required,gt=0"`
+	Price        float64 `json:"price" binding:"gte=0"`
+	TriggerPrice float64 `json:"trigger_price" binding:"gte=0"`
+	TrailAmount  float64 `json:"trail_amount" binding:"gte=0"`                                // TSL only, one of amount or percent
+	TrailPercent float64 `json:"trail_percent" binding:"gte=0,lt=100"`                        // TSL only
+	TimeInForce  string  `json:"time_in_force" binding:"omitempty,oneof=DAY IOC FOK GTC GTD"` // defaults to DAY
+	ExpireDate   string  `json:"expire_date"`                                                 // YYYY-MM-DD, GTD only
+	Product      string  `json:"product" binding:"omitempty,oneof=INTRADAY DELIVERY"`         // defaults to DELIVERY
+}
+
+// BracketOrderRequest places an entry order with target and stop-loss exit
+// legs that become active once the entry fills
+type BracketOrderRequest struct {
+	Symbol        string  `json:"symbol" binding:"required"`
+	Side          string  `json:"side" binding:"required,oneof=BUY SELL"` // side of the entry
+	OrderType     string  `json:"order_type" binding:"required,oneof=MARKET LIMIT"`
+	Quantity      int     `json:"quantity" binding:"required,gt=0"`
+	Price         float64 `json:"price" binding:"gte=0"`
+	TargetPrice   float64 `json:"target_price" binding:"required,gt=0"`
+	StopLossPrice float64 `json:"stop_loss_price" binding:"required,gt=0"`
+}
+
+// OCOOrderRequest places a target limit order and a stop-loss order on the
+// same side; when one fills the other is cancelled
+type OCOOrderRequest struct {
+	Symbol        string  `json:"symbol" binding:"required"`
+	Side          string  `json:"side" binding:"required,oneof=BUY SELL"`
+	Quantity      int     `json:"quantity" binding:"required,gt=0"`
+	TargetPrice   float64 `json:"target_price" binding:"required,gt=0"`
+	StopLossPrice float64 `json:"stop_loss_price" binding:"required,gt=0"`
+	TimeInForce   string  `json:"time_in_force" binding:"omitempty,oneof=DAY GTC GTD"` // defaults to DAY
+	ExpireDate    string  `json:"expire_date"`                                         // YYYY-MM-DD, GTD only
+	Product       string  `json:"product" binding:"omitempty,oneof=INTRADAY DELIVERY"` // defaults to DELIVERY
+}
+
+// Algo order strategies
+const (
+	AlgoIceberg = "ICEBERG" // shows display_quantity at a time, refilled as it fills
+	AlgoTWAP    = "TWAP"    // equal slices spread evenly over the window
+	AlgoVWAP    = "VWAP"    // slices sized by the typical intraday volume profile
+)
+
+// Algo order statuses
+const (
+	AlgoStatusActive    = "ACTIVE"
+	AlgoStatusCompleted = "COMPLETED"
+	AlgoStatusCancelled = "CANCELLED"
+	AlgoStatusExpired   = "EXPIRED"  // the window closed before the quantity filled
+	AlgoStatusRejected  = "REJECTED" // a slice was rejected for a reason retrying cannot fix; see message
+)
+
+// AlgoOrder is a parent order that the algo service works in the market as
+// a series of child orders
+type AlgoOrder struct {
+	ID                string    `json:"id" gorm:"primaryKey"`
+	UserID            uint      `json:"-" gorm:"not null;index"`
+	Strategy          string    `json:"strategy" gorm:"not null"` // ICEBERG, TWAP or VWAP
+	Symbol            string    `json:"symbol" gorm:"not null"`
+	Side              string    `json:"side" gorm:"not null"`
+	Quantity          int       `json:"quantity" gorm:"not null"`
+	Price             float64   `json:"price"` // limit price of every slice; 0 slices at the market
+	DisplayQuantity   int       `json:"display_quantity,omitempty"`
+	Slices            int       `json:"slices,omitempty"`
+	StartTime         time.Time `json:"start_time" gorm:"not null"`
+	EndTime           time.Time `json:"end_time" gorm:"not null"`
+	FilledQuantity    int       `json:"filled_quantity"`
+	AveragePrice      float64   `json:"average_price"`
+	RemainingQuantity int       `json:"remaining_quantity" gorm:"-"`
+	Status            string    `json:"status" gorm:"not null"`
+	Message           string    `json:"message,omitempty"`
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
+	Orders            []Order   `json:"orders,omitempty" gorm:"-"` // child orders, oldest first
+}
+
+// AlgoOrderRequest starts an algo order. The window defaults to now until
+// the end of today's normal session; TWAP and VWAP need an end_time.
+type AlgoOrderRequest struct {
+	Strategy        string     `json:"strategy" binding:"required,oneof=ICEBERG TWAP VWAP"`
+	Symbol          string     `json:"symbol" binding:"required"`
+	Side            string     `json:"side" binding:"required,oneof=BUY SELL"`
+	Quantity        int        `json:"quantity" binding:"required,gt=0"`
+	Price           float64    `json:"price" binding:"gte=0"`
+	DisplayQuantity int        `json:"display_quantity" binding:"gte=0"` // ICEBERG only
+	Slices          int        `json:"slices" binding:"gte=0,lte=500"`   // TWAP and VWAP; defaults to one a minute
+	StartTime       *time.Time `json:"start_time"`
+	EndTime         *time.Time `json:"end_time"`
+}
+
+type AlgoOrdersResponse struct {
+	AlgoOrders []AlgoOrder `json:"algo_orders"`
+}
+
+// GTT types
+const (
+	GTTTypeSingle = "SINGLE" // one trigger
+	GTTTypeOCO    = "OCO"    // a trigger either side of the market; the first to fire wins
+)
+
+// GTT statuses
+const (
+	GTTStatusActive    = "ACTIVE"
+	GTTStatusTriggered = "TRIGGERED" // fired and placed its order
+	GTTStatusRejected  = "REJECTED"  // fired but its order was rejected
+	GTTStatusCancelled = "CANCELLED"
+	GTTStatusExpired   = "EXPIRED"
+)
+
+// GTT trigger conditions, fixed from the last price when the trigger is set
+const (
+	GTTConditionAbove = "ABOVE" // fires when the last price rises to the trigger
+	GTTConditionBelow = "BELOW" // fires when the last price falls to the trigger
+)
+
+// GTT events
+const (
+	GTTEventCreated       = "CREATED"
+	GTTEventModified      = "MODIFIED"
+	GTTEventTriggered     = "TRIGGERED"
+	GTTEventOrderRejected = "ORDER_REJECTED"
+	GTTEventCancelled     = "CANCELLED"
+	GTTEventExpired       = "EXPIRED"
+)
+
+// GTT is a good-till-triggered order: a standing trigger that places a
+// regular order when the last price reaches it
+type GTT struct {
+	ID          string     `json:"id" gorm:"primaryKey"`
+	UserID      uint       `json:"-" gorm:"not null;index"`
+	Type        string     `json:"type" gorm:"not null"` // SINGLE or OCO
+	Symbol      string     `json:"symbol" gorm:"not null"`
+	Side        string     `json:"side" gorm:"not null"`
+	Quantity    int        `json:"quantity" gorm:"not null"`
+	Legs        []GTTLeg   `json:"legs" gorm:"serializer:json;not null"`
+	Status      string     `json:"status" gorm:"not null"`
+	OrderID     string     `json:"order_id,omitempty"` // order placed when triggered
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	ExpiresAt   time.Time  `json:"expires_at" gorm:"not null"`
+	TriggeredAt *time.Time `json:"triggered_at,omitempty"`
+	Events      []GTTEvent `json:"events,omitempty" gorm:"-"`
+}
+
+// GTTLeg is one trigger of a GTT and the order it places
+type GTTLeg struct {
+	TriggerPrice float64 `json:"trigger_price"`
+	Condition    string  `json:"condition"`  // ABOVE or BELOW
+	OrderType    string  `json:"order_type"` // LIMIT or MARKET
+	Price        float64 `json:"price"`      // limit price; 0 for MARKET
+}
+
+// GTTEvent records a change in a GTT's life
+type GTTEvent struct {
+	ID        uint      `json:"-" gorm:"primaryKey"`
+	GTTID     string    `json:"-" gorm:"column:gtt_id;not null;index"`
+	Event     string    `json:"event" gorm:"not null"`
+	LastPrice float64   `json:"last_price,omitempty"`
+	OrderID   string    `json:"order_id,omitempty"`
+	Message   string    `json:"message,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// GTTRequest creates or replaces a GTT. A SINGLE GTT has one leg, an OCO GTT
+// two with triggers either side of the last price.
+type GTTRequest struct {
+	Type     string          `json:"type" binding:"required,oneof=SINGLE OCO"`
+	Symbol   string          `json:"symbol" binding:"required"`
+	Side     string          `json:"side" binding:"required,oneof=BUY SELL"`
+	Quantity int             `json:"quantity" binding:"required,gt=0"`
+	Legs     []GTTLegRequest `json:"legs" binding:"required,min=1,max=2,dive"`
+}
+
+type GTTLegRequest struct {
+	TriggerPrice float64 `json:"trigger_price" binding:"required,gt=0"`
+	OrderType    string  `json:"order_type" binding:"required,oneof=LIMIT MARKET"`
+	Price        float64 `json:"price" binding:"gte=0"`
+}
+
+type GTTsResponse struct {
+	GTTs []GTT `json:"gtts"`
+}
+
+// Trading halt scopes
+const (
+	HaltScopePlatform = "PLATFORM"
+	HaltScopeUser     = "USER"
+	HaltScopeSymbol   = "SYMBOL"
+)
+
+// TradingHalt is a kill switch that stops order placement within its scope
+type TradingHalt struct {
+	Scope           string    `json:"scope"`            // PLATFORM, USER or SYMBOL
+	Target          string    `json:"target,omitempty"` // user ID or symbol; empty for the platform
+	Reason          string    `json:"reason"`
+	HaltedBy        string    `json:"halted_by"` // email of the admin who set it
+	HaltedAt        time.Time `json:"halted_at"`
+	CancelledOrders int       `json:"cancelled_orders,omitempty"` // set in the response to the halt request
+}
+
+type HaltRequest struct {
+	Reason           string `json:"reason" binding:"required"`
+	CancelOpenOrders bool   `json:"cancel_open_orders"`
+}
+
+type HaltsResponse struct {
+	Halts []TradingHalt `json:"halts"`
+}
+
+type InstrumentsResponse struct {
+	Instruments []Instrument `json:"instruments"`
+}
+
+// Quote is a symbol's latest price and session summary
+type Quote struct {
+	Symbol        string    `json:"symbol"`
+	LastPrice     float64   `json:"last_price"`
+	Bid           float64   `json:"bid"`
+	BidQuantity   int       `json:"bid_quantity"`
+	Ask           float64   `json:"ask"`
+	AskQuantity   int       `json:"ask_quantity"`
+	Open          float64   `json:"open"`
+	High          float64   `json:"high"`
+	Low           float64   `json:"low"`
+	PrevClose     float64   `json:"prev_close"`
+	UpperBand     float64   `json:"upper_band,omitempty"`
+	LowerBand     float64   `json:"lower_band,omitempty"`
+	Halted        bool      `json:"halted"`
+	Volume        int64     `json:"volume"`
+	Change        float64   `json:"change"`
+	ChangePercent float64   `json:"change_percent"`
+	Timestamp     time.Time `json:"timestamp"`
+}
+
+type QuotesResponse struct {
+	Quotes []Quote `json:"quotes"`
+}
+
+// DepthLevel is the total resting quantity at one price
+type DepthLevel struct {
+	Price    float64 `json:"price"`
+	Quantity int     `json:"quantity"`
+	Orders   int     `json:"orders"`
+}
+
+// MarketDepth is the aggregated order book for a symbol, best prices first
+type MarketDepth struct {
+	Symbol    string       `json:"symbol"`
+	Bids      []DepthLevel `json:"bids"`
+	Asks      []DepthLevel `json:"asks"`
+	Timestamp time.Time    `json:"timestamp"`
+}
+
+// Candle is one OHLCV bar; OpenTime is the start of the bar's interval
+type Candle struct {
+	ID       uint      `json:"-" gorm:"primaryKey"`
+	Symbol   string    `json:"symbol" gorm:"not null"`
+	Interval string    `json:"interval" gorm:"not null"` // 1m, 5m, 15m, 1h or 1d
+	OpenTime time.Time `json:"open_time" gorm:"not null"`
+	Open     float64   `json:"open"`
+	High     float64   `json:"high"`
+	Low      float64   `json:"low"`
+	Close    float64   `json:"close"`
+	Volume   int64     `json:"volume"`
+}
+
+type CandlesResponse struct {
+	Symbol   string   `json:"symbol"`
+	Interval string   `json:"interval"`
+	Candles  []Candle `json:"candles"`
+}
+
+// MarketStatus describes the exchange session in effect
+type MarketStatus struct {
+	Session          string     `json:"session"` // PRE_OPEN, NORMAL, CLOSING or CLOSED
+	AcceptingOrders  bool       `json:"accepting_orders"`
+	Trading          bool       `json:"trading"` // prices are moving
+	Timezone         string     `json:"timezone"`
+	Time             time.Time  `json:"time"`
+	Holiday          string     `json:"holiday,omitempty"`
+	NextSession      string     `json:"next_session,omitempty"`
+	NextSessionStart *time.Time `json:"next_session_start,omitempty"`
+}
+
 type SuccessResponse struct {
 	Message string      `json:"message"`
 	Data    interface{} `json:"data,omitempty"`
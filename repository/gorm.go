@@ -0,0 +1,315 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+	"trading-platform-backend/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// notFound maps GORM's missing-record error to ErrNotFound
+func notFound(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrNotFound
+	}
+	return err
+}
+
+type gormUserRepository struct {
+	db *gorm.DB
+}
+
+func NewGormUserRepository(db *gorm.DB) UserRepository {
+	return &gormUserRepository{db: db}
+}
+
+func (r *gormUserRepository) Create(ctx context.Context, user *models.User) error {
+	err := r.db.WithContext(ctx).Create(user).Error
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return ErrDuplicate
+	}
+	return err
+}
+
+func (r *gormUserRepository) GetByID(ctx context.Context, id uint) (*models.User, error) {
+	var user models.User
+	if err := r.db.WithContext(ctx).First(&user, id).Error; err != nil {
+		return nil, notFound(err)
+	}
+	return &user, nil
+}
+
+func (r *gormUserRepository) GetByEmail(ctx context.Context, email string) (*models.User, error) {
+	var user models.User
+	if err := r.db.WithContext(ctx).Where("email = ?", email).First(&user).Error; err != nil {
+		return nil, notFound(err)
+	}
+	return &user, nil
+}
+
+func (r *gormUserRepository) Update(ctx context.Context, user *models.User) error {
+	return r.db.WithContext(ctx).Save(user).Error
+}
+
+type gormOrderRepository struct {
+	db *gorm.DB
+}
+
+func NewGormOrderRepository(db *gorm.DB) OrderRepository {
+	return &gormOrderRepository{db: db}
+}
+
+func (r *gormOrderRepository) Create(ctx context.Context, order *models.Order) error {
+	return r.db.WithContext(ctx).Create(order).Error
+}
+
+func (r *gormOrderRepository) Update(ctx context.Context, order *models.Order) error {
+	return r.db.WithContext(ctx).Save(order).Error
+}
+
+func (r *gormOrderRepository) CreateAll(ctx context.Context, orders []*models.Order) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, order := range orders {
+			if err := tx.Create(order).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *gormOrderRepository) UpdateAll(ctx context.Context, orders []*models.Order) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, order := range orders {
+			if err := tx.Save(order).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *gormOrderRepository) GetByID(ctx context.Context, id string) (*models.Order, error) {
+	var order models.Order
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&order).Error; err != nil {
+		return nil, notFound(err)
+	}
+	return &order, nil
+}
+
+func (r *gormOrderRepository) ListByUser(ctx context.Context, userID uint) ([]models.Order, error) {
+	var orders []models.Order
+	err := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("order_time DESC").Find(&orders).Error
+	return orders, err
+}
+
+func (r *gormOrderRepository) ListOpenBySymbol(ctx context.Context, symbol string) ([]models.Order, error) {
+	var orders []models.Order
+	err := r.db.WithContext(ctx).
+		Where("symbol = ? AND status IN ?", symbol, []string{models.OrderStatusPending, models.OrderStatusTriggerPending}).
+		Order("order_time").
+		Find(&orders).Error
+	return orders, err
+}
+
+func (r *gormOrderRepository) ListByParent(ctx context.Context, parentID string) ([]models.Order, error) {
+	var orders []models.Order
+	err := r.db.WithContext(ctx).Where("parent_id = ?", parentID).Order("order_time, id").Find(&orders).Error
+	return orders, err
+}
+
+func (r *gormOrderRepository) ListByAlgo(ctx context.Context, algoID string) ([]models.Order, error) {
+	var orders []models.Order
+	err := r.db.WithContext(ctx).Where("algo_id = ?", algoID).Order("order_time, id").Find(&orders).Error
+	return orders, err
+}
+
+func (r *gormOrderRepository) ListExpired(ctx context.Context, now time.Time) ([]models.Order, error) {
+	var orders []models.Order
+	err := r.db.WithContext(ctx).
+		Where("status IN ? AND expires_at <= ?", []string{models.OrderStatusPending, models.OrderStatusTriggerPending}, now).
+		Order("expires_at").
+		Find(&orders).Error
+	return orders, err
+}
+
+func (r *gormOrderRepository) ListUserIDs(ctx context.Context) ([]uint, error) {
+	var ids []uint
+	err := r.db.WithContext(ctx).Model(&models.Order{}).Distinct("user_id").Order("user_id").Pluck("user_id", &ids).Error
+	return ids, err
+}
+
+type gormAlgoOrderRepository struct {
+	db *gorm.DB
+}
+
+func NewGormAlgoOrderRepository(db *gorm.DB) AlgoOrderRepository {
+	return &gormAlgoOrderRepository{db: db}
+}
+
+func (r *gormAlgoOrderRepository) Create(ctx context.Context, algo *models.AlgoOrder) error {
+	return r.db.WithContext(ctx).Create(algo).Error
+}
+
+func (r *gormAlgoOrderRepository) Update(ctx context.Context, algo *models.AlgoOrder) error {
+	return r.db.WithContext(ctx).Save(algo).Error
+}
+
+func (r *gormAlgoOrderRepository) GetByID(ctx context.Context, id string) (*models.AlgoOrder, error) {
+	var algo models.AlgoOrder
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&algo).Error; err != nil {
+		return nil, notFound(err)
+	}
+	return &algo, nil
+}
+
+func (r *gormAlgoOrderRepository) ListByUser(ctx context.Context, userID uint) ([]models.AlgoOrder, error) {
+	var algos []models.AlgoOrder
+	err := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("created_at DESC").Find(&algos).Error
+	return algos, err
+}
+
+func (r *gormAlgoOrderRepository) ListActive(ctx context.Context) ([]models.AlgoOrder, error) {
+	var algos []models.AlgoOrder
+	err := r.db.WithContext(ctx).Where("status = ?", models.AlgoStatusActive).Order("created_at").Find(&algos).Error
+	return algos, err
+}
+
+type gormGTTRepository struct {
+	db *gorm.DB
+}
+
+func NewGormGTTRepository(db *gorm.DB) GTTRepository {
+	return &gormGTTRepository{db: db}
+}
+
+func (r *gormGTTRepository) Create(ctx context.Context, gtt *models.GTT, event *models.GTTEvent) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(gtt).Error; err != nil {
+			return err
+		}
+		event.GTTID = gtt.ID
+		return tx.Create(event).Error
+	})
+}
+
+func (r *gormGTTRepository) Update(ctx context.Context, gtt *models.GTT, event *models.GTTEvent) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(gtt).Error; err != nil {
+			return err
+		}
+		event.GTTID = gtt.ID
+		return tx.Create(event).Error
+	})
+}
+
+func (r *gormGTTRepository) GetByID(ctx context.Context, id string) (*models.GTT, error) {
+	var gtt models.GTT
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&gtt).Error; err != nil {
+		return nil, notFound(err)
+	}
+	return &gtt, nil
+}
+
+func (r *gormGTTRepository) ListByUser(ctx context.Context, userID uint) ([]models.GTT, error) {
+	var gtts []models.GTT
+	err := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("created_at DESC").Find(&gtts).Error
+	return gtts, err
+}
+
+func (r *gormGTTRepository) ListActiveBySymbol(ctx context.Context, symbol string) ([]models.GTT, error) {
+	var gtts []models.GTT
+	err := r.db.WithContext(ctx).
+		Where("symbol = ? AND status = ?", symbol, models.GTTStatusActive).
+		Order("created_at").
+		Find(&gtts).Error
+	return gtts, err
+}
+
+func (r *gormGTTRepository) ListExpired(ctx context.Context, now time.Time) ([]models.GTT, error) {
+	var gtts []models.GTT
+	err := r.db.WithContext(ctx).
+		Where("status = ? AND expires_at <= ?", models.GTTStatusActive, now).
+		Order("expires_at").
+		Find(&gtts).Error
+	return gtts, err
+}
+
+func (r *gormGTTRepository) ListEvents(ctx context.Context, gttID string) ([]models.GTTEvent, error) {
+	var events []models.GTTEvent
+	err := r.db.WithContext(ctx).Where("gtt_id = ?", gttID).Order("created_at, id").Find(&events).Error
+	return events, err
+}
+
+type gormInstrumentRepository struct {
+	db *gorm.DB
+}
+
+func NewGormInstrumentRepository(db *gorm.DB) InstrumentRepository {
+	return &gormInstrumentRepository{db: db}
+}
+
+func (r *gormInstrumentRepository) List(ctx context.Context) ([]models.Instrument, error) {
+	var instruments []models.Instrument
+	err := r.db.WithContext(ctx).Order("symbol").Find(&instruments).Error
+	return instruments, err
+}
+
+func (r *gormInstrumentRepository) GetBySymbol(ctx context.Context, symbol string) (*models.Instrument, error) {
+	var instrument models.Instrument
+	if err := r.db.WithContext(ctx).Where("symbol = ?", symbol).First(&instrument).Error; err != nil {
+		return nil, notFound(err)
+	}
+	return &instrument, nil
+}
+
+func (r *gormInstrumentRepository) Upsert(ctx context.Context, instruments []models.Instrument) error {
+	if len(instruments) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "symbol"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"exchange", "isin", "name", "instrument_type", "tick_size",
+			"lot_size", "trading_status", "prev_close", "price_band", "var_margin",
+			"elm_margin", "updated_at",
+		}),
+	}).Create(&instruments).Error
+}
+
+type gormCandleRepository struct {
+	db *gorm.DB
+}
+
+func NewGormCandleRepository(db *gorm.DB) CandleRepository {
+	return &gormCandleRepository{db: db}
+}
+
+func (r *gormCandleRepository) Upsert(ctx context.Context, candles []models.Candle) error {
+	if len(candles) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "symbol"}, {Name: "interval"}, {Name: "open_time"}},
+		DoUpdates: clause.AssignmentColumns([]string{"open", "high", "low", "close", "volume"}),
+	}).CreateInBatches(&candles, 500).Error
+}
+
+func (r *gormCandleRepository) List(ctx context.Context, symbol, interval string, from, to time.Time) ([]models.Candle, error) {
+	var candles []models.Candle
+	err := r.db.WithContext(ctx).
+		Where("symbol = ? AND interval = ? AND open_time >= ? AND open_time < ?", symbol, interval, from, to).
+		Order("open_time").
+		Find(&candles).Error
+	return candles, err
+}
+
+func (r *gormCandleRepository) DeleteBefore(ctx context.Context, interval string, cutoff time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Where("interval = ? AND open_time < ?", interval, cutoff).
+		Delete(&models.Candle{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,614 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"trading-platform-backend/models"
+)
+
+// In-memory implementations for tests and local experiments. They are safe
+// for concurrent use but keep no data across restarts.
+
+type memoryUserRepository struct {
+	mu     sync.RWMutex
+	nextID uint
+	users  map[uint]models.User
+}
+
+func NewMemoryUserRepository() UserRepository {
+	return &memoryUserRepository{users: make(map[uint]models.User)}
+}
+
+func (r *memoryUserRepository) Create(ctx context.Context, user *models.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.users {
+		if strings.EqualFold(existing.Email, user.Email) {
+			return ErrDuplicate
+		}
+	}
+
+	r.nextID++
+	now := time.Now()
+	user.ID = r.nextID
+	user.CreatedAt = now
+	user.UpdatedAt = now
+	r.users[user.ID] = *user
+	return nil
+}
+
+func (r *memoryUserRepository) GetByID(ctx context.Context, id uint) (*models.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	user, ok := r.users[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return &user, nil
+}
+
+func (r *memoryUserRepository) GetByEmail(ctx context.Context, email string) (*models.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, user := range r.users {
+		if strings.EqualFold(user.Email, email) {
+			return &user, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+func (r *memoryUserRepository) Update(ctx context.Context, user *models.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.users[user.ID]; !ok {
+		return ErrNotFound
+	}
+	user.UpdatedAt = time.Now()
+	r.users[user.ID] = *user
+	return nil
+}
+
+type memoryOrderRepository struct {
+	mu     sync.RWMutex
+	orders map[string]models.Order
+}
+
+func NewMemoryOrderRepository() OrderRepository {
+	return &memoryOrderRepository{orders: make(map[string]models.Order)}
+}
+
+func (r *memoryOrderRepository) Create(ctx context.Context, order *models.Order) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.orders[order.ID]; ok {
+		return ErrDuplicate
+	}
+	r.orders[order.ID] = *order
+	return nil
+}
+
+func (r *memoryOrderRepository) Update(ctx context.Context, order *models.Order) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.orders[order.ID]; !ok {
+		return ErrNotFound
+	}
+	r.orders[order.ID] = *order
+	return nil
+}
+
+func (r *memoryOrderRepository) CreateAll(ctx context.Context, orders []*models.Order) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, order := range orders {
+		if _, ok := r.orders[order.ID]; ok {
+			return ErrDuplicate
+		}
+	}
+	for _, order := range orders {
+		r.orders[order.ID] = *order
+	}
+	return nil
+}
+
+func (r *memoryOrderRepository) UpdateAll(ctx context.Context, orders []*models.Order) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, order := range orders {
+		if _, ok := r.orders[order.ID]; !ok {
+			return ErrNotFound
+		}
+	}
+	for _, order := range orders {
+		r.orders[order.ID] = *order
+	}
+	return nil
+}
+
+func (r *memoryOrderRepository) GetByID(ctx context.Context, id string) (*models.Order, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	order, ok := r.orders[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return &order, nil
+}
+
+func (r *memoryOrderRepository) ListByUser(ctx context.Context, userID uint) ([]models.Order, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var orders []models.Order
+	for _, order := range r.orders {
+		if order.UserID == userID {
+			orders = append(orders, order)
+		}
+	}
+	sort.Slice(orders, func(i, j int) bool { return orders[i].OrderTime.After(orders[j].OrderTime) })
+	return orders, nil
+}
+
+func (r *memoryOrderRepository) ListOpenBySymbol(ctx context.Context, symbol string) ([]models.Order, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var orders []models.Order
+	for _, order := range r.orders {
+		if order.Symbol == symbol && order.IsOpen() {
+			orders = append(orders, order)
+		}
+	}
+	sort.Slice(orders, func(i, j int) bool { return orders[i].OrderTime.Before(orders[j].OrderTime) })
+	return orders, nil
+}
+
+func (r *memoryOrderRepository) ListByParent(ctx context.Context, parentID string) ([]models.Order, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var orders []models.Order
+	for _, order := range r.orders {
+		if order.ParentID == parentID {
+			orders = append(orders, order)
+		}
+	}
+	sort.Slice(orders, func(i, j int) bool {
+		if !orders[i].OrderTime.Equal(orders[j].OrderTime) {
+			return orders[i].OrderTime.Before(orders[j].OrderTime)
+		}
+		return orders[i].ID < orders[j].ID
+	})
+	return orders, nil
+}
+
+func (r *memoryOrderRepository) ListByAlgo(ctx context.Context, algoID string) ([]models.Order, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var orders []models.Order
+	for _, order := range r.orders {
+		if order.AlgoID == algoID {
+			orders = append(orders, order)
+		}
+	}
+	sort.Slice(orders, func(i, j int) bool {
+		if !orders[i].OrderTime.Equal(orders[j].OrderTime) {
+			return orders[i].OrderTime.Before(orders[j].OrderTime)
+		}
+		return orders[i].ID < orders[j].ID
+	})
+	return orders, nil
+}
+
+func (r *memoryOrderRepository) ListExpired(ctx context.Context, now time.Time) ([]models.Order, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var orders []models.Order
+	for _, order := range r.orders {
+		if order.IsOpen() && order.ExpiresAt != nil && !order.ExpiresAt.After(now) {
+			orders = append(orders, order)
+		}
+	}
+	sort.Slice(orders, func(i, j int) bool { return orders[i].ExpiresAt.Before(*orders[j].ExpiresAt) })
+	return orders, nil
+}
+
+func (r *memoryOrderRepository) ListUserIDs(ctx context.Context) ([]uint, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	seen := make(map[uint]bool)
+	var ids []uint
+	for _, order := range r.orders {
+		if !seen[order.UserID] {
+			seen[order.UserID] = true
+			ids = append(ids, order.UserID)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids, nil
+}
+
+type memoryAlgoOrderRepository struct {
+	mu    sync.RWMutex
+	algos map[string]models.AlgoOrder
+}
+
+func NewMemoryAlgoOrderRepository() AlgoOrderRepository {
+	return &memoryAlgoOrderRepository{algos: make(map[string]models.AlgoOrder)}
+}
+
+func (r *memoryAlgoOrderRepository) Create(ctx context.Context, algo *models.AlgoOrder) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.algos[algo.ID]; ok {
+		return ErrDuplicate
+	}
+	r.algos[algo.ID] = *algo
+	return nil
+}
+
+func (r *memoryAlgoOrderRepository) Update(ctx context.Context, algo *models.AlgoOrder) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.algos[algo.ID]; !ok {
+		return ErrNotFound
+	}
+	r.algos[algo.ID] = *algo
+	return nil
+}
+
+func (r *memoryAlgoOrderRepository) GetByID(ctx context.Context, id string) (*models.AlgoOrder, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	algo, ok := r.algos[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return &algo, nil
+}
+
+func (r *memoryAlgoOrderRepository) ListByUser(ctx context.Context, userID uint) ([]models.AlgoOrder, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var algos []models.AlgoOrder
+	for _, algo := range r.algos {
+		if algo.UserID == userID {
+			algos = append(algos, algo)
+		}
+	}
+	sort.Slice(algos, func(i, j int) bool { return algos[i].CreatedAt.After(algos[j].CreatedAt) })
+	return algos, nil
+}
+
+func (r *memoryAlgoOrderRepository) ListActive(ctx context.Context) ([]models.AlgoOrder, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var algos []models.AlgoOrder
+	for _, algo := range r.algos {
+		if algo.Status == models.AlgoStatusActive {
+			algos = append(algos, algo)
+		}
+	}
+	sort.Slice(algos, func(i, j int) bool { return algos[i].CreatedAt.Before(algos[j].CreatedAt) })
+	return algos, nil
+}
+
+type memoryGTTRepository struct {
+	mu     sync.RWMutex
+	gtts   map[string]models.GTT
+	events []models.GTTEvent
+}
+
+func NewMemoryGTTRepository() GTTRepository {
+	return &memoryGTTRepository{gtts: make(map[string]models.GTT)}
+}
+
+func (r *memoryGTTRepository) Create(ctx context.Context, gtt *models.GTT, event *models.GTTEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.gtts[gtt.ID]; ok {
+		return ErrDuplicate
+	}
+	r.gtts[gtt.ID] = *gtt
+	r.addEvent(gtt.ID, event)
+	return nil
+}
+
+func (r *memoryGTTRepository) Update(ctx context.Context, gtt *models.GTT, event *models.GTTEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.gtts[gtt.ID]; !ok {
+		return ErrNotFound
+	}
+	r.gtts[gtt.ID] = *gtt
+	r.addEvent(gtt.ID, event)
+	return nil
+}
+
+func (r *memoryGTTRepository) addEvent(gttID string, event *models.GTTEvent) {
+	event.ID = uint(len(r.events) + 1)
+	event.GTTID = gttID
+	r.events = append(r.events, *event)
+}
+
+func (r *memoryGTTRepository) GetByID(ctx context.Context, id string) (*models.GTT, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	gtt, ok := r.gtts[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return &gtt, nil
+}
+
+func (r *memoryGTTRepository) ListByUser(ctx context.Context, userID uint) ([]models.GTT, error) {
+	return r.list(func(gtt models.GTT) bool { return gtt.UserID == userID }, func(a, b models.GTT) bool {
+		return a.CreatedAt.After(b.CreatedAt)
+	}), nil
+}
+
+func (r *memoryGTTRepository) ListActiveBySymbol(ctx context.Context, symbol string) ([]models.GTT, error) {
+	return r.list(func(gtt models.GTT) bool {
+		return gtt.Symbol == symbol && gtt.Status == models.GTTStatusActive
+	}, func(a, b models.GTT) bool {
+		return a.CreatedAt.Before(b.CreatedAt)
+	}), nil
+}
+
+func (r *memoryGTTRepository) ListExpired(ctx context.Context, now time.Time) ([]models.GTT, error) {
+	return r.list(func(gtt models.GTT) bool {
+		return gtt.Status == models.GTTStatusActive && !gtt.ExpiresAt.After(now)
+	}, func(a, b models.GTT) bool {
+		return a.ExpiresAt.Before(b.ExpiresAt)
+	}), nil
+}
+
+func (r *memoryGTTRepository) list(keep func(models.GTT) bool, less func(a, b models.GTT) bool) []models.GTT {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var gtts []models.GTT
+	for _, gtt := range r.gtts {
+		if keep(gtt) {
+			gtts = append(gtts, gtt)
+		}
+	}
+	sort.Slice(gtts, func(i, j int) bool { return less(gtts[i], gtts[j]) })
+	return gtts
+}
+
+func (r *memoryGTTRepository) ListEvents(ctx context.Context, gttID string) ([]models.GTTEvent, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var events []models.GTTEvent
+	for _, event := range r.events {
+		if event.GTTID == gttID {
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
+
+type memoryInstrumentRepository struct {
+	mu          sync.RWMutex
+	instruments map[string]models.Instrument
+}
+
+func NewMemoryInstrumentRepository() InstrumentRepository {
+	return &memoryInstrumentRepository{instruments: make(map[string]models.Instrument)}
+}
+
+func (r *memoryInstrumentRepository) List(ctx context.Context) ([]models.Instrument, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	instruments := make([]models.Instrument, 0, len(r.instruments))
+	for _, instrument := range r.instruments {
+		instruments = append(instruments, instrument)
+	}
+	sort.Slice(instruments, func(i, j int) bool { return instruments[i].Symbol < instruments[j].Symbol })
+	return instruments, nil
+}
+
+func (r *memoryInstrumentRepository) GetBySymbol(ctx context.Context, symbol string) (*models.Instrument, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	instrument, ok := r.instruments[symbol]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return &instrument, nil
+}
+
+func (r *memoryInstrumentRepository) Upsert(ctx context.Context, instruments []models.Instrument) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	for _, instrument := range instruments {
+		if existing, ok := r.instruments[instrument.Symbol]; ok {
+			instrument.ID = existing.ID
+			instrument.CreatedAt = existing.CreatedAt
+		} else {
+			instrument.ID = uint(len(r.instruments) + 1)
+			instrument.CreatedAt = now
+		}
+		instrument.UpdatedAt = now
+		r.instruments[instrument.Symbol] = instrument
+	}
+	return nil
+}
+
+type candleKey struct {
+	symbol   string
+	interval string
+	openTime int64
+}
+
+type memoryCandleRepository struct {
+	mu      sync.RWMutex
+	candles map[candleKey]models.Candle
+}
+
+func NewMemoryCandleRepository() CandleRepository {
+	return &memoryCandleRepository{candles: make(map[candleKey]models.Candle)}
+}
+
+func (r *memoryCandleRepository) Upsert(ctx context.Context, candles []models.Candle) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, candle := range candles {
+		key := candleKey{candle.Symbol, candle.Interval, candle.OpenTime.UnixNano()}
+		if existing, ok := r.candles[key]; ok {
+			candle.ID = existing.ID
+		} else {
+			candle.ID = uint(len(r.candles) + 1)
+		}
+		r.candles[key] = candle
+	}
+	return nil
+}
+
+func (r *memoryCandleRepository) List(ctx context.Context, symbol, interval string, from, to time.Time) ([]models.Candle, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var candles []models.Candle
+	for key, candle := range r.candles {
+		if key.symbol == symbol && key.interval == interval && !candle.OpenTime.Before(from) && candle.OpenTime.Before(to) {
+			candles = append(candles, candle)
+		}
+	}
+	sort.Slice(candles, func(i, j int) bool { return candles[i].OpenTime.Before(candles[j].OpenTime) })
+	return candles, nil
+}
+
+func (r *memoryCandleRepository) DeleteBefore(ctx context.Context, interval string, cutoff time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var deleted int64
+	for key, candle := range r.candles {
+		if key.interval == interval && candle.OpenTime.Before(cutoff) {
+			delete(r.candles, key)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+type memoryRateLimitStore struct {
+	mu      sync.Mutex
+	windows map[string]rateWindow
+}
+
+type rateWindow struct {
+	count     int
+	expiresAt time.Time
+}
+
+func NewMemoryRateLimitStore() RateLimitStore {
+	return &memoryRateLimitStore{windows: make(map[string]rateWindow)}
+}
+
+func (s *memoryRateLimitStore) Attempts(ctx context.Context, key string) (int, time.Duration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	w, ok := s.windows[key]
+	remaining := time.Until(w.expiresAt)
+	if !ok || remaining <= 0 {
+		return 0, 0, nil
+	}
+	return w.count, remaining, nil
+}
+
+func (s *memoryRateLimitStore) RecordAttempt(ctx context.Context, key string, window time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	w := s.windows[key]
+	if time.Now().After(w.expiresAt) {
+		w.count = 0
+	}
+	w.count++
+	w.expiresAt = time.Now().Add(window)
+	s.windows[key] = w
+	return nil
+}
+
+type memoryHaltStore struct {
+	mu    sync.RWMutex
+	halts map[HaltKey]models.TradingHalt
+}
+
+func NewMemoryHaltStore() HaltStore {
+	return &memoryHaltStore{halts: make(map[HaltKey]models.TradingHalt)}
+}
+
+func (s *memoryHaltStore) Set(ctx context.Context, halt models.TradingHalt) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.halts[HaltKey{Scope: halt.Scope, Target: halt.Target}] = halt
+	return nil
+}
+
+func (s *memoryHaltStore) Delete(ctx context.Context, key HaltKey) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.halts[key]
+	delete(s.halts, key)
+	return ok, nil
+}
+
+func (s *memoryHaltStore) Find(ctx context.Context, keys ...HaltKey) ([]models.TradingHalt, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var halts []models.TradingHalt
+	for _, key := range keys {
+		if halt, ok := s.halts[key]; ok {
+			halts = append(halts, halt)
+		}
+	}
+	return halts, nil
+}
+
+func (s *memoryHaltStore) List(ctx context.Context) ([]models.TradingHalt, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	halts := make([]models.TradingHalt, 0, len(s.halts))
+	for _, halt := range s.halts {
+		halts = append(halts, halt)
+	}
+	sort.Slice(halts, func(i, j int) bool { return halts[i].HaltedAt.Before(halts[j].HaltedAt) })
+	return halts, nil
+}
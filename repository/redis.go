@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"time"
+	"trading-platform-backend/models"
+
+	"github.com/go-redis/redis/v8"
+)
+
+type redisRateLimitStore struct {
+	client *redis.Client
+}
+
+func NewRedisRateLimitStore(client *redis.Client) RateLimitStore {
+	return &redisRateLimitStore{client: client}
+}
+
+func (s *redisRateLimitStore) Attempts(ctx context.Context, key string) (int, time.Duration, error) {
+	pipe := s.client.Pipeline()
+	get := pipe.Get(ctx, key)
+	ttl := pipe.TTL(ctx, key)
+	if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+		return 0, 0, err
+	}
+
+	count, err := get.Int()
+	if err == redis.Nil {
+		return 0, 0, nil
+	}
+	if err != nil {
+		return 0, 0, err
+	}
+	return count, ttl.Val(), nil
+}
+
+func (s *redisRateLimitStore) RecordAttempt(ctx context.Context, key string, window time.Duration) error {
+	pipe := s.client.Pipeline()
+	pipe.Incr(ctx, key)
+	pipe.Expire(ctx, key, window)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// haltsKey is the Redis hash holding every trading halt, keyed by scope:target
+const haltsKey = "trading:halts"
+
+type redisHaltStore struct {
+	client *redis.Client
+}
+
+func NewRedisHaltStore(client *redis.Client) HaltStore {
+	return &redisHaltStore{client: client}
+}
+
+func (s *redisHaltStore) Set(ctx context.Context, halt models.TradingHalt) error {
+	value, err := json.Marshal(halt)
+	if err != nil {
+		return err
+	}
+	key := HaltKey{Scope: halt.Scope, Target: halt.Target}
+	return s.client.HSet(ctx, haltsKey, key.String(), value).Err()
+}
+
+func (s *redisHaltStore) Delete(ctx context.Context, key HaltKey) (bool, error) {
+	n, err := s.client.HDel(ctx, haltsKey, key.String()).Result()
+	return n > 0, err
+}
+
+func (s *redisHaltStore) Find(ctx context.Context, keys ...HaltKey) ([]models.TradingHalt, error) {
+	fields := make([]string, len(keys))
+	for i, key := range keys {
+		fields[i] = key.String()
+	}
+	values, err := s.client.HMGet(ctx, haltsKey, fields...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	var halts []models.TradingHalt
+	for _, value := range values {
+		raw, ok := value.(string)
+		if !ok {
+			continue
+		}
+		var halt models.TradingHalt
+		if err := json.Unmarshal([]byte(raw), &halt); err != nil {
+			return nil, err
+		}
+		halts = append(halts, halt)
+	}
+	return halts, nil
+}
+
+func (s *redisHaltStore) List(ctx context.Context) ([]models.TradingHalt, error) {
+	values, err := s.client.HGetAll(ctx, haltsKey).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	halts := make([]models.TradingHalt, 0, len(values))
+	for _, raw := range values {
+		var halt models.TradingHalt
+		if err := json.Unmarshal([]byte(raw), &halt); err != nil {
+			return nil, err
+		}
+		halts = append(halts, halt)
+	}
+	sort.Slice(halts, func(i, j int) bool { return halts[i].HaltedAt.Before(halts[j].HaltedAt) })
+	return halts, nil
+}
@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+	"trading-platform-backend/models"
+)
+
+var (
+	// ErrNotFound is returned when the requested record does not exist
+	ErrNotFound = errors.New("record not found")
+	// ErrDuplicate is returned when a unique constraint would be violated
+	ErrDuplicate = errors.New("record already exists")
+)
+
+// UserRepository persists user accounts
+type UserRepository interface {
+	Create(ctx context.Context, user *models.User) error
+	GetByID(ctx context.Context, id uint) (*models.User, error)
+	GetByEmail(ctx context.Context, email string) (*models.User, error)
+	Update(ctx context.Context, user *models.User) error
+}
+
+// OrderRepository persists orders
+type OrderRepository interface {
+	Create(ctx context.Context, order *models.Order) error
+	Update(ctx context.Context, order *models.Order) error
+	// CreateAll inserts linked orders in a single transaction
+	CreateAll(ctx context.Context, orders []*models.Order) error
+	// UpdateAll saves linked orders in a single transaction
+	UpdateAll(ctx context.Context, orders []*models.Order) error
+	GetByID(ctx context.Context, id string) (*models.Order, error)
+	// ListByUser returns the user's orders, newest first
+	ListByUser(ctx context.Context, userID uint) ([]models.Order, error)
+	// ListOpenBySymbol returns every user's open (pending or trigger pending)
+	// orders for symbol, oldest first
+	ListOpenBySymbol(ctx context.Context, symbol string) ([]models.Order, error)
+	// ListByParent returns the legs linked to a parent order, oldest first
+	ListByParent(ctx context.Context, parentID string) ([]models.Order, error)
+	// ListByAlgo returns the child orders of an algo order, oldest first
+	ListByAlgo(ctx context.Context, algoID string) ([]models.Order, error)
+	// ListExpired returns open orders whose expiry is at or before now
+	ListExpired(ctx context.Context, now time.Time) ([]models.Order, error)
+	// ListUserIDs returns every user who has placed an order, in ascending order
+	ListUserIDs(ctx context.Context) ([]uint, error)
+}
+
+// AlgoOrderRepository persists algo parent orders
+type AlgoOrderRepository interface {
+	Create(ctx context.Context, algo *models.AlgoOrder) error
+	Update(ctx context.Context, algo *models.AlgoOrder) error
+	GetByID(ctx context.Context, id string) (*models.AlgoOrder, error)
+	// ListByUser returns the user's algo orders, newest first
+	ListByUser(ctx context.Context, userID uint) ([]models.AlgoOrder, error)
+	// ListActive returns every user's active algo orders, oldest first
+	ListActive(ctx context.Context) ([]models.AlgoOrder, error)
+}
+
+// GTTRepository persists GTT triggers along with their event history. Every
+// write records an event in the same transaction.
+type GTTRepository interface {
+	Create(ctx context.Context, gtt *models.GTT, event *models.GTTEvent) error
+	Update(ctx context.Context, gtt *models.GTT, event *models.GTTEvent) error
+	GetByID(ctx context.Context, id string) (*models.GTT, error)
+	// ListByUser returns the user's GTTs, newest first
+	ListByUser(ctx context.Context, userID uint) ([]models.GTT, error)
+	// ListActiveBySymbol returns every user's active GTTs for symbol, oldest first
+	ListActiveBySymbol(ctx context.Context, symbol string) ([]models.GTT, error)
+	// ListExpired returns active GTTs whose expiry is at or before now
+	ListExpired(ctx context.Context, now time.Time) ([]models.GTT, error)
+	// ListEvents returns a GTT's history, oldest first
+	ListEvents(ctx context.Context, gttID string) ([]models.GTTEvent, error)
+}
+
+// InstrumentRepository persists the instrument master
+type InstrumentRepository interface {
+	List(ctx context.Context) ([]models.Instrument, error)
+	GetBySymbol(ctx context.Context, symbol string) (*models.Instrument, error)
+	// Upsert inserts new instruments and updates existing ones by symbol
+	Upsert(ctx context.Context, instruments []models.Instrument) error
+}
+
+// CandleRepository persists OHLCV candles, unique per symbol, interval and open time
+type CandleRepository interface {
+	// Upsert inserts candles or replaces existing bars with the same key
+	Upsert(ctx context.Context, candles []models.Candle) error
+	// List returns candles with from <= open time < to, oldest first
+	List(ctx context.Context, symbol, interval string, from, to time.Time) ([]models.Candle, error)
+	// DeleteBefore removes candles of interval opened before cutoff and returns how many were removed
+	DeleteBefore(ctx context.Context, interval string, cutoff time.Time) (int64, error)
+}
+
+// HaltStore keeps trading halts where every instance sees them at once
+type HaltStore interface {
+	Set(ctx context.Context, halt models.TradingHalt) error
+	// Delete lifts a halt and reports whether it existed
+	Delete(ctx context.Context, key HaltKey) (bool, error)
+	// Find returns the halts that exist among keys
+	Find(ctx context.Context, keys ...HaltKey) ([]models.TradingHalt, error)
+	// List returns every halt, oldest first
+	List(ctx context.Context) ([]models.TradingHalt, error)
+}
+
+// HaltKey identifies a halt by scope and target
+type HaltKey struct {
+	Scope  string
+	Target string
+}
+
+func (k HaltKey) String() string {
+	return k.Scope + ":" + k.Target
+}
+
+// RateLimitStore counts attempts per key within an expiring window
+type RateLimitStore interface {
+	// Attempts returns the attempts recorded for key and the time until the window resets
+	Attempts(ctx context.Context, key string) (int, time.Duration, error)
+	// RecordAttempt increments the counter for key and (re)starts its window
+	RecordAttempt(ctx context.Context, key string, window time.Duration) error
+}
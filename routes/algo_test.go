@@ -0,0 +1,215 @@
+package routes_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+	"trading-platform-backend/config"
+	"trading-platform-backend/models"
+	"trading-platform-backend/services"
+)
+
+func withAlwaysOpen() serverOption {
+	return func(cfg *config.Config) { cfg.MarketConfig.AlwaysOpen = true }
+}
+
+func (s *testServer) createAlgo(token string, req models.AlgoOrderRequest) models.AlgoOrder {
+	s.t.Helper()
+	w := s.do(http.MethodPost, "/api/v1/algos", req, token)
+	expectStatus(s.t, w, http.StatusCreated)
+	return decode[models.AlgoOrder](s.t, w)
+}
+
+func (s *testServer) getAlgo(token, id string) models.AlgoOrder {
+	s.t.Helper()
+	w := s.do(http.MethodGet, "/api/v1/algos/"+id, nil, token)
+	expectStatus(s.t, w, http.StatusOK)
+	return decode[models.AlgoOrder](s.t, w)
+}
+
+// runAlgos moves the clock to at and works the active algo orders
+func (s *testServer) runAlgos(at time.Time) {
+	s.t.Helper()
+	s.setTime(at)
+	if err := s.algos.Run(context.Background(), at); err != nil {
+		s.t.Fatalf("run algo orders: %v", err)
+	}
+}
+
+func TestIcebergOrder(t *testing.T) {
+	s := newTestServer(t)
+	token := s.signup("iceberg@example.com", "secret123").AccessToken
+
+	// RELIANCE last price before trading is its previous close, 2485.20
+	algo := s.createAlgo(token, models.AlgoOrderRequest{
+		Strategy: "ICEBERG", Symbol: "RELIANCE", Side: "BUY", Quantity: 10, Price: 2480, DisplayQuantity: 3,
+	})
+	if len(algo.Orders) != 1 || algo.Orders[0].Quantity != 3 || algo.Orders[0].AlgoID != algo.ID || algo.RemainingQuantity != 10 {
+		t.Fatalf("iceberg = %+v, want one visible slice of 3", algo)
+	}
+
+	// No new slice while the visible one rests
+	s.runAlgos(tradingHours.Add(time.Minute))
+	if got := s.getAlgo(token, algo.ID); len(got.Orders) != 1 {
+		t.Fatalf("iceberg has %d slices while the first rests, want 1", len(got.Orders))
+	}
+
+	s.tick("RELIANCE", 2479)
+	s.runAlgos(tradingHours.Add(2 * time.Minute))
+	algo = s.getAlgo(token, algo.ID)
+	if algo.FilledQuantity != 3 || algo.AveragePrice != 2479 || algo.RemainingQuantity != 7 || len(algo.Orders) != 2 {
+		t.Fatalf("iceberg after the first slice filled = %+v, want 3 filled and a refill", algo)
+	}
+
+	w := s.do(http.MethodDelete, "/api/v1/algos/"+algo.ID, nil, token)
+	expectStatus(t, w, http.StatusOK)
+	algo = decode[models.AlgoOrder](t, w)
+	if algo.Status != models.AlgoStatusCancelled || algo.Orders[1].Status != models.OrderStatusCancelled || algo.FilledQuantity != 3 {
+		t.Fatalf("cancelled iceberg = %+v, want the open slice cancelled", algo)
+	}
+	expectStatus(t, s.do(http.MethodDelete, "/api/v1/algos/"+algo.ID, nil, token), http.StatusConflict)
+
+	other := s.signup("iceberg-other@example.com", "secret123").AccessToken
+	expectStatus(t, s.do(http.MethodGet, "/api/v1/algos/"+algo.ID, nil, other), http.StatusNotFound)
+}
+
+func TestTWAPOrder(t *testing.T) {
+	s := newTestServer(t)
+	token := s.signup("twap@example.com", "secret123").AccessToken
+
+	end := tradingHours.Add(10 * time.Minute)
+	algo := s.createAlgo(token, models.AlgoOrderRequest{
+		Strategy: "TWAP", Symbol: "RELIANCE", Side: "BUY", Quantity: 10, Slices: 5, EndTime: &end,
+	})
+	if algo.FilledQuantity != 2 || algo.Status != models.AlgoStatusActive {
+		t.Fatalf("TWAP after its first slice = %+v, want 2 filled", algo)
+	}
+
+	s.runAlgos(tradingHours.Add(time.Minute))
+	if got := s.getAlgo(token, algo.ID); got.FilledQuantity != 2 {
+		t.Fatalf("TWAP before its second slice is due = %d filled, want 2", got.FilledQuantity)
+	}
+	s.runAlgos(tradingHours.Add(4 * time.Minute))
+	if got := s.getAlgo(token, algo.ID); got.FilledQuantity != 6 || len(got.Orders) != 2 {
+		t.Fatalf("TWAP after three slices are due = %d filled in %d orders, want 6 in 2", got.FilledQuantity, len(got.Orders))
+	}
+	s.runAlgos(tradingHours.Add(9 * time.Minute))
+	if got := s.getAlgo(token, algo.ID); got.FilledQuantity != 10 || got.Status != models.AlgoStatusCompleted || got.RemainingQuantity != 0 {
+		t.Fatalf("TWAP after its last slice = %+v, want COMPLETED", got)
+	}
+
+	w := s.do(http.MethodGet, "/api/v1/algos", nil, token)
+	expectStatus(t, w, http.StatusOK)
+	if algos := decode[models.AlgoOrdersResponse](t, w).AlgoOrders; len(algos) != 1 || algos[0].FilledQuantity != 10 {
+		t.Fatalf("algo list = %+v", algos)
+	}
+}
+
+func TestAlgoOrderRetriesAfterHalt(t *testing.T) {
+	s := newTestServer(t)
+	token := s.signup("algo-halt@example.com", "secret123").AccessToken
+	admin := s.signupAdmin("algo-halt-admin@example.com")
+
+	end := tradingHours.Add(10 * time.Minute)
+	algo := s.createAlgo(token, models.AlgoOrderRequest{
+		Strategy: "TWAP", Symbol: "RELIANCE", Side: "BUY", Quantity: 10, Slices: 5, EndTime: &end,
+	})
+
+	// A halted slice is retried rather than ending the algo order
+	s.halt(admin, "symbols/reliance", models.HaltRequest{Reason: "corporate action"})
+	s.runAlgos(tradingHours.Add(2 * time.Minute))
+	got := s.getAlgo(token, algo.ID)
+	if got.Status != models.AlgoStatusActive || got.FilledQuantity != 2 || !strings.HasPrefix(got.Message, services.RejectTradingHalted) {
+		t.Fatalf("TWAP during a halt = %+v, want ACTIVE with the rejection recorded", got)
+	}
+
+	// Once trading resumes the missed slice is caught up
+	expectStatus(t, s.do(http.MethodDelete, "/api/v1/admin/halts/symbols/reliance", nil, admin), http.StatusOK)
+	s.runAlgos(tradingHours.Add(4 * time.Minute))
+	if got := s.getAlgo(token, algo.ID); got.Status != models.AlgoStatusActive || got.FilledQuantity != 6 || got.Message != "" {
+		t.Fatalf("TWAP after the halt = %+v, want 6 filled", got)
+	}
+}
+
+func TestVWAPOrder(t *testing.T) {
+	s := newTestServer(t)
+	token := s.signup("vwap@example.com", "secret123").AccessToken
+
+	// 10:30 - 11:30 spans four 15-minute buckets weighted 4.0, 3.7, 3.5 and 3.3
+	end := tradingHours.Add(time.Hour)
+	algo := s.createAlgo(token, models.AlgoOrderRequest{
+		Strategy: "VWAP", Symbol: "INFY", Side: "SELL", Quantity: 100, Slices: 4, EndTime: &end,
+	})
+	if algo.FilledQuantity != 27 {
+		t.Fatalf("VWAP first slice = %d, want 27 (front-loaded by the volume profile)", algo.FilledQuantity)
+	}
+
+	// The window closes with a limit slice unfilled
+	end = tradingHours.Add(10 * time.Minute)
+	limited := s.createAlgo(token, models.AlgoOrderRequest{
+		Strategy: "VWAP", Symbol: "INFY", Side: "SELL", Quantity: 10, Price: 1900, Slices: 2, EndTime: &end,
+	})
+	s.runAlgos(end)
+	limited = s.getAlgo(token, limited.ID)
+	if limited.Status != models.AlgoStatusExpired || limited.Orders[0].Status != models.OrderStatusCancelled {
+		t.Fatalf("VWAP after its window = %+v, want EXPIRED with its slice cancelled", limited)
+	}
+}
+
+func TestAlgoOrderValidation(t *testing.T) {
+	s := newTestServer(t)
+	token := s.signup("algo-validation@example.com", "secret123").AccessToken
+
+	end := tradingHours.Add(time.Hour)
+	late := time.Date(2026, 10, 20, 15, 45, 0, 0, ist)
+	tests := []struct {
+		name string
+		req  models.AlgoOrderRequest
+		code string
+	}{
+		{"iceberg without price", models.AlgoOrderRequest{Strategy: "ICEBERG", DisplayQuantity: 2}, services.RejectInvalidAlgo},
+		{"iceberg display too large", models.AlgoOrderRequest{Strategy: "ICEBERG", Price: 2480, DisplayQuantity: 10}, services.RejectInvalidAlgo},
+		{"TWAP without end", models.AlgoOrderRequest{Strategy: "TWAP"}, services.RejectInvalidAlgo},
+		{"TWAP with display quantity", models.AlgoOrderRequest{Strategy: "TWAP", EndTime: &end, DisplayQuantity: 2}, services.RejectInvalidAlgo},
+		{"window past the close", models.AlgoOrderRequest{Strategy: "VWAP", EndTime: &late}, services.RejectInvalidAlgo},
+		{"unknown symbol", models.AlgoOrderRequest{Strategy: "TWAP", Symbol: "ACME", EndTime: &end}, services.RejectUnknownSymbol},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.req.Symbol == "" {
+				tt.req.Symbol = "RELIANCE"
+			}
+			tt.req.Side, tt.req.Quantity = "BUY", 10
+			w := s.do(http.MethodPost, "/api/v1/algos", tt.req, token)
+			expectStatus(t, w, http.StatusUnprocessableEntity)
+			if code := decode[models.ErrorResponse](t, w).Code; code != tt.code {
+				t.Fatalf("code = %q, want %q", code, tt.code)
+			}
+		})
+	}
+}
+
+func TestAlgoOrderAlwaysOpen(t *testing.T) {
+	s := newTestServer(t, withAlwaysOpen())
+	token := s.signup("algo-always-open@example.com", "secret123").AccessToken
+
+	// After the NSE close the market is still open, so algos may run on
+	evening := time.Date(2026, 10, 20, 19, 0, 0, 0, ist)
+	s.setTime(evening)
+	end := evening.Add(10 * time.Minute)
+	algo := s.createAlgo(token, models.AlgoOrderRequest{
+		Strategy: "TWAP", Symbol: "RELIANCE", Side: "BUY", Quantity: 10, Slices: 5, EndTime: &end,
+	})
+	if algo.Status != models.AlgoStatusActive || algo.FilledQuantity != 2 {
+		t.Fatalf("TWAP after the NSE close = %+v, want its first slice filled", algo)
+	}
+
+	// The window is still bounded to a day ahead
+	late := evening.Add(25 * time.Hour)
+	w := s.do(http.MethodPost, "/api/v1/algos", models.AlgoOrderRequest{
+		Strategy: "TWAP", Symbol: "RELIANCE", Side: "BUY", Quantity: 10, EndTime: &late,
+	}, token)
+	expectStatus(t, w, http.StatusUnprocessableEntity)
+}
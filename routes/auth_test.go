@@ -0,0 +1,172 @@
+package routes_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+	"trading-platform-backend/models"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func TestSignup(t *testing.T) {
+	s := newTestServer(t)
+
+	tokens := s.signup("trader@example.com", "password123")
+	if tokens.AccessToken == "" || tokens.RefreshToken == "" {
+		t.Fatalf("signup returned empty tokens: %+v", tokens)
+	}
+	if tokens.ExpiresIn != int(s.cfg.JWTExpiresIn.Seconds()) {
+		t.Errorf("expires_in = %d, want %d", tokens.ExpiresIn, int(s.cfg.JWTExpiresIn.Seconds()))
+	}
+
+	t.Run("duplicate email", func(t *testing.T) {
+		w := s.do(http.MethodPost, "/api/v1/auth/signup", models.SignupRequest{Email: "trader@example.com", Password: "password123"}, "")
+		expectStatus(t, w, http.StatusBadRequest)
+		if resp := decode[models.ErrorResponse](t, w); resp.Message != "user already exists" {
+			t.Errorf("message = %q, want %q", resp.Message, "user already exists")
+		}
+	})
+
+	t.Run("invalid payload", func(t *testing.T) {
+		for name, body := range map[string]any{
+			"bad email":      models.SignupRequest{Email: "not-an-email", Password: "password123"},
+			"short password": models.SignupRequest{Email: "short@example.com", Password: "abc"},
+			"missing fields": map[string]string{},
+		} {
+			w := s.do(http.MethodPost, "/api/v1/auth/signup", body, "")
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("%s: status = %d, want %d", name, w.Code, http.StatusBadRequest)
+			}
+		}
+	})
+}
+
+func TestLogin(t *testing.T) {
+	s := newTestServer(t)
+	s.signup("trader@example.com", "password123")
+
+	t.Run("valid credentials", func(t *testing.T) {
+		w := s.do(http.MethodPost, "/api/v1/auth/login", models.LoginRequest{Email: "trader@example.com", Password: "password123"}, "")
+		expectStatus(t, w, http.StatusOK)
+		if resp := decode[models.AuthResponse](t, w); resp.AccessToken == "" {
+			t.Error("login returned empty access token")
+		}
+	})
+
+	t.Run("wrong password", func(t *testing.T) {
+		w := s.do(http.MethodPost, "/api/v1/auth/login", models.LoginRequest{Email: "trader@example.com", Password: "wrong"}, "")
+		expectStatus(t, w, http.StatusBadRequest)
+		if resp := decode[models.ErrorResponse](t, w); resp.Error != "Invalid credentials" {
+			t.Errorf("error = %q, want %q", resp.Error, "Invalid credentials")
+		}
+	})
+
+	t.Run("unknown email", func(t *testing.T) {
+		w := s.do(http.MethodPost, "/api/v1/auth/login", models.LoginRequest{Email: "nobody@example.com", Password: "password123"}, "")
+		expectStatus(t, w, http.StatusBadRequest)
+	})
+}
+
+func TestDisabledUser(t *testing.T) {
+	s := newTestServer(t)
+	tokens := s.signup("trader@example.com", "password123")
+
+	if _, err := s.auth.SetUserDisabled(context.Background(), "trader@example.com", true); err != nil {
+		t.Fatal(err)
+	}
+
+	w := s.do(http.MethodPost, "/api/v1/auth/login", models.LoginRequest{Email: "trader@example.com", Password: "password123"}, "")
+	expectStatus(t, w, http.StatusForbidden)
+
+	w = s.do(http.MethodPost, "/api/v1/auth/refresh", map[string]string{"refresh_token": tokens.RefreshToken}, "")
+	expectStatus(t, w, http.StatusUnauthorized)
+
+	if _, err := s.auth.SetUserDisabled(context.Background(), "trader@example.com", false); err != nil {
+		t.Fatal(err)
+	}
+	w = s.do(http.MethodPost, "/api/v1/auth/login", models.LoginRequest{Email: "trader@example.com", Password: "password123"}, "")
+	expectStatus(t, w, http.StatusOK)
+}
+
+func TestInspectToken(t *testing.T) {
+	s := newTestServer(t)
+	tokens := s.signup("trader@example.com", "password123")
+
+	for token, kind := range map[string]string{tokens.AccessToken: "access", tokens.RefreshToken: "refresh"} {
+		inspection, err := s.auth.InspectToken(token)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !inspection.Valid || inspection.Kind != kind {
+			t.Errorf("inspect %s token: valid=%v kind=%q error=%q", kind, inspection.Valid, inspection.Kind, inspection.Error)
+		}
+	}
+}
+
+func TestRefreshTokenRotation(t *testing.T) {
+	s := newTestServer(t)
+	initial := s.signup("trader@example.com", "password123")
+
+	w := s.do(http.MethodPost, "/api/v1/auth/refresh", map[string]string{"refresh_token": initial.RefreshToken}, "")
+	expectStatus(t, w, http.StatusOK)
+	rotated := decode[models.AuthResponse](t, w)
+
+	// The new access token must be usable
+	expectStatus(t, s.do(http.MethodGet, "/api/v1/holdings", nil, rotated.AccessToken), http.StatusOK)
+
+	t.Run("rotated token works", func(t *testing.T) {
+		w := s.do(http.MethodPost, "/api/v1/auth/refresh", map[string]string{"refresh_token": rotated.RefreshToken}, "")
+		expectStatus(t, w, http.StatusOK)
+	})
+
+	t.Run("access token is not a refresh token", func(t *testing.T) {
+		w := s.do(http.MethodPost, "/api/v1/auth/refresh", map[string]string{"refresh_token": initial.AccessToken}, "")
+		expectStatus(t, w, http.StatusUnauthorized)
+	})
+
+	t.Run("garbage token", func(t *testing.T) {
+		w := s.do(http.MethodPost, "/api/v1/auth/refresh", map[string]string{"refresh_token": "not.a.jwt"}, "")
+		expectStatus(t, w, http.StatusUnauthorized)
+	})
+}
+
+func TestProtectedRoutesRequireValidToken(t *testing.T) {
+	s := newTestServer(t)
+	tokens := s.signup("trader@example.com", "password123")
+
+	forged := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"user_id": 1,
+		"exp":     time.Now().Add(time.Hour).Unix(),
+	})
+	forgedToken, err := forged.SignedString([]byte("some-other-secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, path := range []string{"/api/v1/holdings", "/api/v1/orderbook", "/api/v1/positions"} {
+		t.Run(path, func(t *testing.T) {
+			expectStatus(t, s.do(http.MethodGet, path, nil, tokens.AccessToken), http.StatusOK)
+			expectStatus(t, s.do(http.MethodGet, path, nil, ""), http.StatusUnauthorized)
+			expectStatus(t, s.do(http.MethodGet, path, nil, forgedToken), http.StatusUnauthorized)
+			expectStatus(t, s.do(http.MethodGet, path, nil, tokens.RefreshToken), http.StatusUnauthorized)
+		})
+	}
+
+	t.Run("malformed header", func(t *testing.T) {
+		w := s.doWithHeader(http.MethodGet, "/api/v1/holdings", "Authorization", "Token "+tokens.AccessToken)
+		expectStatus(t, w, http.StatusUnauthorized)
+		if resp := decode[models.ErrorResponse](t, w); resp.Error != "Invalid authorization format" {
+			t.Errorf("error = %q, want %q", resp.Error, "Invalid authorization format")
+		}
+	})
+}
+
+func TestExpiredAccessToken(t *testing.T) {
+	s := newTestServer(t, withAccessTTL(-time.Minute))
+	tokens := s.signup("trader@example.com", "password123")
+
+	w := s.do(http.MethodGet, "/api/v1/holdings", nil, tokens.AccessToken)
+	expectStatus(t, w, http.StatusUnauthorized)
+}
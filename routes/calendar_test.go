@@ -0,0 +1,81 @@
+package routes_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+	"trading-platform-backend/models"
+	"trading-platform-backend/services"
+)
+
+func TestMarketStatus(t *testing.T) {
+	s := newTestServer(t)
+
+	tests := []struct {
+		name        string
+		at          time.Time
+		session     string
+		accepting   bool
+		holiday     string
+		nextSession string
+		nextStart   time.Time
+	}{
+		{"normal", tradingHours, services.SessionNormal, true, "", services.SessionClosing, time.Date(2026, 10, 20, 15, 40, 0, 0, ist)},
+		{"pre-open", time.Date(2026, 10, 20, 9, 5, 0, 0, ist), services.SessionPreOpen, true, "", services.SessionNormal, time.Date(2026, 10, 20, 9, 15, 0, 0, ist)},
+		{"closing price gap", time.Date(2026, 10, 20, 15, 35, 0, 0, ist), services.SessionClosed, false, "", services.SessionClosing, time.Date(2026, 10, 20, 15, 40, 0, 0, ist)},
+		{"weekend", time.Date(2026, 10, 24, 11, 0, 0, 0, ist), services.SessionClosed, false, "", services.SessionPreOpen, time.Date(2026, 10, 26, 9, 0, 0, 0, ist)},
+		{"holiday", time.Date(2026, 10, 2, 11, 0, 0, 0, ist), services.SessionClosed, false, "Mahatma Gandhi Jayanti", services.SessionPreOpen, time.Date(2026, 10, 5, 9, 0, 0, 0, ist)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s.setTime(tt.at)
+
+			w := s.do(http.MethodGet, "/api/v1/market/status", nil, "")
+			expectStatus(t, w, http.StatusOK)
+			status := decode[models.MarketStatus](t, w)
+			if status.Session != tt.session || status.AcceptingOrders != tt.accepting || status.Holiday != tt.holiday {
+				t.Fatalf("status = %+v", status)
+			}
+			if status.NextSession != tt.nextSession || status.NextSessionStart == nil || !status.NextSessionStart.Equal(tt.nextStart) {
+				t.Fatalf("next session = %s at %v, want %s at %v", status.NextSession, status.NextSessionStart, tt.nextSession, tt.nextStart)
+			}
+		})
+	}
+}
+
+func TestOrdersOutsideSession(t *testing.T) {
+	s := newTestServer(t)
+	token := s.signup("session@example.com", "secret123").AccessToken
+	order := models.PlaceOrderRequest{Symbol: "TCS", Side: "BUY", OrderType: "LIMIT", Quantity: 1, Price: 3790}
+
+	s.setTime(time.Date(2026, 10, 20, 20, 0, 0, 0, ist))
+	w := s.do(http.MethodPost, "/api/v1/orders", order, token)
+	expectStatus(t, w, http.StatusUnprocessableEntity)
+	if code := decode[models.ErrorResponse](t, w).Code; code != services.RejectMarketClosed {
+		t.Fatalf("code = %q, want %q", code, services.RejectMarketClosed)
+	}
+
+	// Pre-open orders are accepted and wait for the open
+	s.setTime(time.Date(2026, 10, 20, 9, 2, 0, 0, ist))
+	w = s.do(http.MethodPost, "/api/v1/orders", order, token)
+	expectStatus(t, w, http.StatusCreated)
+	if status := decode[models.Order](t, w).Status; status != models.OrderStatusPending {
+		t.Fatalf("pre-open order status = %q", status)
+	}
+}
+
+func TestSimulatorPausesWhenClosed(t *testing.T) {
+	s := newTestServer(t)
+
+	s.setTime(time.Date(2026, 10, 24, 11, 0, 0, 0, ist))
+	s.prices.Step()
+	if snapshot, _ := s.prices.Snapshot("TCS"); snapshot.Volume != 0 {
+		t.Fatalf("simulator traded on a weekend: %+v", snapshot)
+	}
+
+	s.setTime(tradingHours)
+	s.prices.Step()
+	if snapshot, _ := s.prices.Snapshot("TCS"); snapshot.Volume == 0 {
+		t.Fatal("simulator did not trade during the normal session")
+	}
+}
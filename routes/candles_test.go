@@ -0,0 +1,119 @@
+package routes_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+	"trading-platform-backend/models"
+	"trading-platform-backend/services"
+)
+
+func TestCandlesFromTicks(t *testing.T) {
+	s := newTestServer(t)
+	token := s.signup("charts@example.com", "secret123").AccessToken
+
+	for range 10 {
+		s.prices.Step()
+	}
+
+	// Ticks are stamped with the market clock, so query around it
+	window := fmt.Sprintf("from=%d&to=%d", tradingHours.Add(-time.Hour).Unix(), tradingHours.Add(time.Hour).Unix())
+
+	w := s.do(http.MethodGet, "/api/v1/candles/tcs?interval=1m&"+window, nil, token)
+	expectStatus(t, w, http.StatusOK)
+	resp := decode[models.CandlesResponse](t, w)
+	if resp.Symbol != "TCS" || resp.Interval != "1m" || len(resp.Candles) == 0 {
+		t.Fatalf("candles = %+v", resp)
+	}
+	var volume int64
+	for _, c := range resp.Candles {
+		if c.High < c.Low || c.Open > c.High || c.Close < c.Low {
+			t.Fatalf("inconsistent candle %+v", c)
+		}
+		volume += c.Volume
+	}
+
+	// Without a window the latest bars up to the market clock are returned
+	w = s.do(http.MethodGet, "/api/v1/candles/TCS?interval=1m", nil, token)
+	expectStatus(t, w, http.StatusOK)
+	if latest := decode[models.CandlesResponse](t, w).Candles; len(latest) != len(resp.Candles) {
+		t.Fatalf("default window has %d candles, want %d", len(latest), len(resp.Candles))
+	}
+
+	// Flushed bars are served from the repository with the same totals
+	if err := s.candles.Flush(context.Background()); err != nil {
+		t.Fatalf("flush: %v", err)
+	}
+	w = s.do(http.MethodGet, "/api/v1/candles/TCS?interval=1d&from=2026-10-20T00:00:00%2B05:30&to=2026-10-21T00:00:00%2B05:30", nil, token)
+	expectStatus(t, w, http.StatusOK)
+	daily := decode[models.CandlesResponse](t, w).Candles
+	if len(daily) != 1 || daily[0].Volume != volume {
+		t.Fatalf("daily candles = %+v, want one bar with volume %d", daily, volume)
+	}
+}
+
+func TestCandlesBackfillAndQuery(t *testing.T) {
+	s := newTestServer(t)
+	token := s.signup("backfill@example.com", "secret123").AccessToken
+
+	csv := `symbol,interval,open_time,open,high,low,close,volume
+INFY,5m,2025-01-06T03:45:00Z,1850,1856.5,1848,1855,1200
+INFY,5m,2025-01-06T03:50:00Z,1855,1860,1853.2,1858.4,900
+INFY,5m,2025-01-06T03:55:00Z,1858.4,1859,1851,1852,1500
+`
+	imported, err := s.candles.ImportCSV(context.Background(), strings.NewReader(csv))
+	if err != nil || imported != 3 {
+		t.Fatalf("import = %d, %v", imported, err)
+	}
+
+	w := s.do(http.MethodGet, "/api/v1/candles/INFY?interval=5m&from=2025-01-06T03:50:00Z&to=2025-01-06T04:00:00Z", nil, token)
+	expectStatus(t, w, http.StatusOK)
+	candles := decode[models.CandlesResponse](t, w).Candles
+	if len(candles) != 2 || candles[0].Close != 1858.4 || candles[1].Volume != 1500 {
+		t.Fatalf("candles = %+v", candles)
+	}
+
+	// Misaligned bars are rejected with the offending line
+	bad := "symbol,interval,open_time,open,high,low,close,volume\nINFY,5m,2025-01-06T03:47:00Z,1,1,1,1,1\n"
+	if _, err := s.candles.ImportCSV(context.Background(), strings.NewReader(bad)); err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Fatalf("misaligned import err = %v", err)
+	}
+}
+
+func TestCandlesValidation(t *testing.T) {
+	s := newTestServer(t)
+	token := s.signup("candles@example.com", "secret123").AccessToken
+
+	expectStatus(t, s.do(http.MethodGet, "/api/v1/candles/TCS?interval=2m", nil, token), http.StatusBadRequest)
+	expectStatus(t, s.do(http.MethodGet, "/api/v1/candles/TCS?from=2025-01-02T00:00:00Z&to=2025-01-01T00:00:00Z", nil, token), http.StatusBadRequest)
+	expectStatus(t, s.do(http.MethodGet, "/api/v1/candles/TCS?interval=1m&from=0", nil, token), http.StatusBadRequest)
+	expectStatus(t, s.do(http.MethodGet, "/api/v1/candles/ACME", nil, token), http.StatusNotFound)
+}
+
+func TestCandlesContinueStoredBar(t *testing.T) {
+	s := newTestServer(t)
+	token := s.signup("restart@example.com", "secret123").AccessToken
+
+	// A bar flushed before a restart, for the minute the market clock is in
+	csv := "symbol,interval,open_time,open,high,low,close,volume\nINFY,1m,2026-10-20T05:00:00Z,1850,1860,1845,1855,1000\n"
+	if _, err := s.candles.ImportCSV(context.Background(), strings.NewReader(csv)); err != nil {
+		t.Fatalf("import: %v", err)
+	}
+
+	s.candles.OnTick(services.Tick{Symbol: "INFY", Price: 1870, Volume: 50, Time: tradingHours.Add(10 * time.Second)})
+	if err := s.candles.Flush(context.Background()); err != nil {
+		t.Fatalf("flush: %v", err)
+	}
+
+	w := s.do(http.MethodGet, "/api/v1/candles/INFY?interval=1m&from=2026-10-20T05:00:00Z&to=2026-10-20T05:01:00Z", nil, token)
+	expectStatus(t, w, http.StatusOK)
+	want := models.Candle{Open: 1850, High: 1870, Low: 1845, Close: 1870, Volume: 1050}
+	candles := decode[models.CandlesResponse](t, w).Candles
+	if len(candles) != 1 || candles[0].Open != want.Open || candles[0].High != want.High || candles[0].Low != want.Low ||
+		candles[0].Close != want.Close || candles[0].Volume != want.Volume {
+		t.Fatalf("candles = %+v, want the stored bar continued to %+v", candles, want)
+	}
+}
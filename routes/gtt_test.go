@@ -0,0 +1,219 @@
+package routes_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+	"trading-platform-backend/models"
+	"trading-platform-backend/services"
+)
+
+// gttTick feeds a crafted trade price to the GTT service
+func (s *testServer) gttTick(symbol string, price float64) {
+	s.t.Helper()
+	if err := s.gtts.ProcessTick(context.Background(), services.Tick{Symbol: symbol, Price: price, Volume: 1, Time: tradingHours}); err != nil {
+		s.t.Fatalf("process GTT tick: %v", err)
+	}
+}
+
+func (s *testServer) getGTT(token, id string) models.GTT {
+	s.t.Helper()
+	w := s.do(http.MethodGet, "/api/v1/gtt/"+id, nil, token)
+	expectStatus(s.t, w, http.StatusOK)
+	return decode[models.GTT](s.t, w)
+}
+
+func (s *testServer) createGTT(token string, req models.GTTRequest) models.GTT {
+	s.t.Helper()
+	w := s.do(http.MethodPost, "/api/v1/gtt", req, token)
+	expectStatus(s.t, w, http.StatusCreated)
+	return decode[models.GTT](s.t, w)
+}
+
+func eventNames(gtt models.GTT) []string {
+	var names []string
+	for _, event := range gtt.Events {
+		names = append(names, event.Event)
+	}
+	return names
+}
+
+func TestGTTSingleTrigger(t *testing.T) {
+	s := newTestServer(t)
+	token := s.signup("gtt@example.com", "secret123").AccessToken
+
+	// RELIANCE last price before trading is its previous close, 2485.20
+	gtt := s.createGTT(token, models.GTTRequest{
+		Type: "SINGLE", Symbol: "RELIANCE", Side: "BUY", Quantity: 2,
+		Legs: []models.GTTLegRequest{{TriggerPrice: 2400, OrderType: "LIMIT", Price: 2401}},
+	})
+	if gtt.Status != models.GTTStatusActive || gtt.Legs[0].Condition != models.GTTConditionBelow {
+		t.Fatalf("GTT = %+v, want an active trigger below the market", gtt)
+	}
+	if want := tradingHours.Add(365 * 24 * time.Hour); !gtt.ExpiresAt.Equal(want) {
+		t.Fatalf("expires at %s, want %s", gtt.ExpiresAt, want)
+	}
+
+	s.gttTick("RELIANCE", 2450)
+	if got := s.getGTT(token, gtt.ID); got.Status != models.GTTStatusActive {
+		t.Fatalf("GTT after a tick above its trigger = %s, want ACTIVE", got.Status)
+	}
+
+	s.gttTick("RELIANCE", 2399.95)
+	gtt = s.getGTT(token, gtt.ID)
+	if gtt.Status != models.GTTStatusTriggered || gtt.OrderID == "" || gtt.TriggeredAt == nil {
+		t.Fatalf("GTT after its trigger traded = %+v", gtt)
+	}
+	if names := eventNames(gtt); len(names) != 2 || names[0] != models.GTTEventCreated || names[1] != models.GTTEventTriggered {
+		t.Fatalf("events = %v, want CREATED then TRIGGERED", names)
+	}
+	if last := gtt.Events[1]; last.OrderID != gtt.OrderID || last.LastPrice != 2399.95 {
+		t.Fatalf("trigger event = %+v", last)
+	}
+
+	order := s.getOrder(token, gtt.OrderID)
+	if order.Side != "BUY" || order.OrderType != "LIMIT" || order.Price != 2401 || order.Quantity != 2 {
+		t.Fatalf("placed order = %+v", order)
+	}
+
+	// A triggered GTT fires only once
+	s.gttTick("RELIANCE", 2390)
+	w := s.do(http.MethodGet, "/api/v1/orderbook", nil, token)
+	expectStatus(t, w, http.StatusOK)
+	if orders := decode[models.OrderbookResponse](t, w).Orders; len(orders) != 1 {
+		t.Fatalf("orderbook has %d orders, want the single GTT order", len(orders))
+	}
+}
+
+func TestGTTOCO(t *testing.T) {
+	s := newTestServer(t)
+	token := s.signup("gtt-oco@example.com", "secret123").AccessToken
+
+	gtt := s.createGTT(token, models.GTTRequest{
+		Type: "OCO", Symbol: "RELIANCE", Side: "SELL", Quantity: 1,
+		Legs: []models.GTTLegRequest{
+			{TriggerPrice: 2550, OrderType: "LIMIT", Price: 2549},
+			{TriggerPrice: 2400, OrderType: "MARKET"},
+		},
+	})
+	if gtt.Legs[0].Condition != models.GTTConditionAbove || gtt.Legs[1].Condition != models.GTTConditionBelow {
+		t.Fatalf("OCO legs = %+v", gtt.Legs)
+	}
+
+	s.gttTick("RELIANCE", 2551)
+	gtt = s.getGTT(token, gtt.ID)
+	if gtt.Status != models.GTTStatusTriggered {
+		t.Fatalf("OCO after the upper trigger = %s, want TRIGGERED", gtt.Status)
+	}
+	if order := s.getOrder(token, gtt.OrderID); order.OrderType != "LIMIT" || order.Price != 2549 {
+		t.Fatalf("placed order = %+v, want the upper leg's limit order", order)
+	}
+}
+
+func TestGTTLifecycle(t *testing.T) {
+	s := newTestServer(t)
+	token := s.signup("gtt-life@example.com", "secret123").AccessToken
+
+	// GTTs can be set outside market hours
+	s.setTime(time.Date(2026, 10, 25, 20, 0, 0, 0, ist))
+	req := models.GTTRequest{
+		Type: "SINGLE", Symbol: "INFY", Side: "BUY", Quantity: 5,
+		Legs: []models.GTTLegRequest{{TriggerPrice: 1500, OrderType: "MARKET"}},
+	}
+	gtt := s.createGTT(token, req)
+	s.setTime(tradingHours)
+
+	req.Legs[0].TriggerPrice = 1550
+	w := s.do(http.MethodPut, "/api/v1/gtt/"+gtt.ID, req, token)
+	expectStatus(t, w, http.StatusOK)
+	if modified := decode[models.GTT](t, w); modified.Legs[0].TriggerPrice != 1550 || !modified.ExpiresAt.Equal(gtt.ExpiresAt) {
+		t.Fatalf("modified GTT = %+v", modified)
+	}
+
+	other := s.signup("gtt-other@example.com", "secret123").AccessToken
+	expectStatus(t, s.do(http.MethodGet, "/api/v1/gtt/"+gtt.ID, nil, other), http.StatusNotFound)
+	expectStatus(t, s.do(http.MethodDelete, "/api/v1/gtt/"+gtt.ID, nil, other), http.StatusNotFound)
+
+	w = s.do(http.MethodGet, "/api/v1/gtt", nil, token)
+	expectStatus(t, w, http.StatusOK)
+	if gtts := decode[models.GTTsResponse](t, w).GTTs; len(gtts) != 1 || gtts[0].ID != gtt.ID {
+		t.Fatalf("GTT list = %+v", gtts)
+	}
+
+	expectStatus(t, s.do(http.MethodDelete, "/api/v1/gtt/"+gtt.ID, nil, token), http.StatusOK)
+	expectStatus(t, s.do(http.MethodDelete, "/api/v1/gtt/"+gtt.ID, nil, token), http.StatusConflict)
+	expectStatus(t, s.do(http.MethodPut, "/api/v1/gtt/"+gtt.ID, req, token), http.StatusConflict)
+
+	gtt = s.getGTT(token, gtt.ID)
+	if names := eventNames(gtt); gtt.Status != models.GTTStatusCancelled || len(names) != 3 || names[1] != models.GTTEventModified || names[2] != models.GTTEventCancelled {
+		t.Fatalf("cancelled GTT = %s with events %v", gtt.Status, names)
+	}
+
+	// Unfired GTTs expire after a year
+	gtt = s.createGTT(token, req)
+	if n, err := s.gtts.ExpireGTTs(context.Background(), gtt.ExpiresAt); err != nil || n != 1 {
+		t.Fatalf("ExpireGTTs = %d, %v; want 1 expired", n, err)
+	}
+	if got := s.getGTT(token, gtt.ID); got.Status != models.GTTStatusExpired {
+		t.Fatalf("GTT after its expiry = %s, want EXPIRED", got.Status)
+	}
+}
+
+func TestGTTRejectedOrder(t *testing.T) {
+	s := newTestServer(t, withHaltedSymbols("RELIANCE"))
+	token := s.signup("gtt-halted@example.com", "secret123").AccessToken
+
+	gtt := s.createGTT(token, models.GTTRequest{
+		Type: "SINGLE", Symbol: "RELIANCE", Side: "SELL", Quantity: 1,
+		Legs: []models.GTTLegRequest{{TriggerPrice: 2500, OrderType: "MARKET"}},
+	})
+	s.gttTick("RELIANCE", 2500)
+
+	gtt = s.getGTT(token, gtt.ID)
+	if gtt.Status != models.GTTStatusRejected || gtt.OrderID != "" {
+		t.Fatalf("GTT fired while trading is halted = %+v, want REJECTED", gtt)
+	}
+	if last := gtt.Events[len(gtt.Events)-1]; last.Event != models.GTTEventOrderRejected || last.Message == "" {
+		t.Fatalf("last event = %+v, want the rejection reason", last)
+	}
+}
+
+func TestGTTValidation(t *testing.T) {
+	s := newTestServer(t)
+	token := s.signup("gtt-validation@example.com", "secret123").AccessToken
+
+	leg := func(trigger float64) models.GTTLegRequest {
+		return models.GTTLegRequest{TriggerPrice: trigger, OrderType: "MARKET"}
+	}
+	tests := []struct {
+		name string
+		req  models.GTTRequest
+		code string
+	}{
+		{"unknown symbol", models.GTTRequest{Type: "SINGLE", Symbol: "ACME", Legs: []models.GTTLegRequest{leg(100)}}, services.RejectUnknownSymbol},
+		{"single with two legs", models.GTTRequest{Type: "SINGLE", Legs: []models.GTTLegRequest{leg(2400), leg(2550)}}, services.RejectInvalidGTT},
+		{"OCO on one side", models.GTTRequest{Type: "OCO", Legs: []models.GTTLegRequest{leg(2400), leg(2300)}}, services.RejectInvalidGTT},
+		{"trigger at the market", models.GTTRequest{Type: "SINGLE", Legs: []models.GTTLegRequest{leg(2485.20)}}, services.RejectInvalidTrigger},
+		{"trigger off tick", models.GTTRequest{Type: "SINGLE", Legs: []models.GTTLegRequest{leg(2400.01)}}, services.RejectInvalidTick},
+		{"limit without price", models.GTTRequest{Type: "SINGLE", Legs: []models.GTTLegRequest{{TriggerPrice: 2400, OrderType: "LIMIT"}}}, services.RejectPriceRequired},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.req.Symbol == "" {
+				tt.req.Symbol = "RELIANCE"
+			}
+			tt.req.Side, tt.req.Quantity = "BUY", 1
+			w := s.do(http.MethodPost, "/api/v1/gtt", tt.req, token)
+			expectStatus(t, w, http.StatusUnprocessableEntity)
+			if code := decode[models.ErrorResponse](t, w).Code; code != tt.code {
+				t.Fatalf("code = %q, want %q", code, tt.code)
+			}
+		})
+	}
+
+	expectStatus(t, s.do(http.MethodPost, "/api/v1/gtt", models.GTTRequest{
+		Type: "SINGLE", Symbol: "RELIANCE", Side: "BUY", Quantity: 1,
+		Legs: []models.GTTLegRequest{{TriggerPrice: 2400, OrderType: "SL-M"}},
+	}, token), http.StatusBadRequest)
+}
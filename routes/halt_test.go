@@ -0,0 +1,148 @@
+package routes_test
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"testing"
+	"trading-platform-backend/models"
+	"trading-platform-backend/services"
+)
+
+// signupAdmin creates a user with the admin role and returns a token carrying it
+func (s *testServer) signupAdmin(email string) string {
+	s.t.Helper()
+	s.signup(email, "secret123")
+	if _, err := s.auth.SetUserRole(context.Background(), email, models.RoleAdmin); err != nil {
+		s.t.Fatalf("set role: %v", err)
+	}
+	w := s.do(http.MethodPost, "/api/v1/auth/login", models.LoginRequest{Email: email, Password: "secret123"}, "")
+	expectStatus(s.t, w, http.StatusOK)
+	return decode[models.AuthResponse](s.t, w).AccessToken
+}
+
+func (s *testServer) halt(token, path string, req models.HaltRequest) models.TradingHalt {
+	s.t.Helper()
+	w := s.do(http.MethodPost, "/api/v1/admin/halts/"+path, req, token)
+	expectStatus(s.t, w, http.StatusCreated)
+	return decode[models.TradingHalt](s.t, w)
+}
+
+func TestHaltRequiresAdmin(t *testing.T) {
+	s := newTestServer(t)
+	trader := s.signup("halt-trader@example.com", "secret123").AccessToken
+
+	req := models.HaltRequest{Reason: "incident"}
+	expectStatus(t, s.do(http.MethodPost, "/api/v1/admin/halts/platform", req, ""), http.StatusUnauthorized)
+	expectStatus(t, s.do(http.MethodPost, "/api/v1/admin/halts/platform", req, trader), http.StatusForbidden)
+	expectStatus(t, s.do(http.MethodGet, "/api/v1/admin/halts", nil, trader), http.StatusForbidden)
+}
+
+func TestSymbolHalt(t *testing.T) {
+	s := newTestServer(t)
+	admin := s.signupAdmin("halt-admin@example.com")
+	trader := s.signup("halt-symbol@example.com", "secret123").AccessToken
+
+	reliance := s.placeOrder(trader, models.PlaceOrderRequest{Symbol: "RELIANCE", Side: "BUY", OrderType: "LIMIT", Quantity: 1, Price: 2400})
+	infy := s.placeOrder(trader, models.PlaceOrderRequest{Symbol: "INFY", Side: "BUY", OrderType: "LIMIT", Quantity: 1, Price: 1800})
+
+	halt := s.halt(admin, "symbols/reliance", models.HaltRequest{Reason: "corporate action", CancelOpenOrders: true})
+	if halt.Scope != models.HaltScopeSymbol || halt.Target != "RELIANCE" || halt.HaltedBy != "halt-admin@example.com" || halt.CancelledOrders != 1 {
+		t.Fatalf("halt = %+v", halt)
+	}
+	if got := s.getOrder(trader, reliance.ID); got.Status != models.OrderStatusCancelled {
+		t.Fatalf("RELIANCE order = %s, want CANCELLED", got.Status)
+	}
+	if got := s.getOrder(trader, infy.ID); got.Status != models.OrderStatusPending {
+		t.Fatalf("INFY order = %s, want PENDING", got.Status)
+	}
+
+	market := models.PlaceOrderRequest{Symbol: "RELIANCE", Side: "BUY", OrderType: "MARKET", Quantity: 1}
+	s.expectRejected(trader, market, services.RejectTradingHalted)
+	s.placeOrder(trader, models.PlaceOrderRequest{Symbol: "INFY", Side: "BUY", OrderType: "MARKET", Quantity: 1})
+
+	w := s.do(http.MethodGet, "/api/v1/admin/halts", nil, admin)
+	expectStatus(t, w, http.StatusOK)
+	if halts := decode[models.HaltsResponse](t, w).Halts; len(halts) != 1 || halts[0].Reason != "corporate action" {
+		t.Fatalf("halts = %+v", halts)
+	}
+
+	expectStatus(t, s.do(http.MethodDelete, "/api/v1/admin/halts/symbols/reliance", nil, admin), http.StatusOK)
+	expectStatus(t, s.do(http.MethodDelete, "/api/v1/admin/halts/symbols/reliance", nil, admin), http.StatusNotFound)
+	s.placeOrder(trader, market)
+}
+
+func TestUserAndPlatformHalts(t *testing.T) {
+	s := newTestServer(t)
+	admin := s.signupAdmin("halt-ops@example.com")
+	halted := s.signup("halt-user@example.com", "secret123").AccessToken
+	other := s.signup("halt-other@example.com", "secret123").AccessToken
+
+	claims, err := s.auth.ValidateToken(halted)
+	if err != nil {
+		t.Fatalf("validate token: %v", err)
+	}
+	s.placeLinked("/api/v1/orders/oco", halted, models.OCOOrderRequest{
+		Symbol: "RELIANCE", Side: "SELL", Quantity: 1, TargetPrice: 2550, StopLossPrice: 2400,
+	})
+
+	// An OCO pair counts once: its stop leg goes with the target
+	halt := s.halt(admin, "users/"+strconv.FormatUint(uint64(claims.UserID), 10), models.HaltRequest{Reason: "suspicious activity", CancelOpenOrders: true})
+	if halt.CancelledOrders != 1 {
+		t.Fatalf("cancelled %d orders, want 1", halt.CancelledOrders)
+	}
+
+	market := models.PlaceOrderRequest{Symbol: "INFY", Side: "BUY", OrderType: "MARKET", Quantity: 1}
+	s.expectRejected(halted, market, services.RejectTradingHalted)
+	s.placeOrder(other, market)
+
+	s.halt(admin, "platform", models.HaltRequest{Reason: "exchange connectivity"})
+	s.expectRejected(other, market, services.RejectTradingHalted)
+	w := s.do(http.MethodPost, "/api/v1/orders/bracket", models.BracketOrderRequest{
+		Symbol: "INFY", Side: "BUY", OrderType: "MARKET", Quantity: 1, TargetPrice: 1900, StopLossPrice: 1800,
+	}, other)
+	expectStatus(t, w, http.StatusUnprocessableEntity)
+
+	expectStatus(t, s.do(http.MethodDelete, "/api/v1/admin/halts/platform", nil, admin), http.StatusOK)
+	s.placeOrder(other, market)
+	s.expectRejected(halted, market, services.RejectTradingHalted)
+
+	expectStatus(t, s.do(http.MethodPost, "/api/v1/admin/halts/users/9999", models.HaltRequest{Reason: "x"}, admin), http.StatusNotFound)
+	expectStatus(t, s.do(http.MethodPost, "/api/v1/admin/halts/symbols/ACME", models.HaltRequest{Reason: "x"}, admin), http.StatusNotFound)
+	expectStatus(t, s.do(http.MethodPost, "/api/v1/admin/halts/platform", models.HaltRequest{}, admin), http.StatusBadRequest)
+}
+
+func TestHaltedOrdersDoNotFill(t *testing.T) {
+	s := newTestServer(t)
+	admin := s.signupAdmin("halt-fills@example.com")
+	halted := s.signup("halt-fills-user@example.com", "secret123").AccessToken
+	other := s.signup("halt-fills-other@example.com", "secret123").AccessToken
+
+	claims, err := s.auth.ValidateToken(halted)
+	if err != nil {
+		t.Fatalf("validate token: %v", err)
+	}
+	limit := models.PlaceOrderRequest{Symbol: "RELIANCE", Side: "BUY", OrderType: "LIMIT", Quantity: 1, Price: 2400}
+	mine := s.placeOrder(halted, limit)
+	theirs := s.placeOrder(other, limit)
+
+	// Without cancel_open_orders resting orders stay open but do not fill
+	s.halt(admin, "symbols/RELIANCE", models.HaltRequest{Reason: "corporate action"})
+	s.tick("RELIANCE", 2390)
+	for _, order := range []models.Order{s.getOrder(halted, mine.ID), s.getOrder(other, theirs.ID)} {
+		if order.Status != models.OrderStatusPending {
+			t.Fatalf("order under a symbol halt = %s, want PENDING", order.Status)
+		}
+	}
+	expectStatus(t, s.do(http.MethodDelete, "/api/v1/admin/halts/symbols/RELIANCE", nil, admin), http.StatusOK)
+
+	// A user halt only holds that user's orders
+	s.halt(admin, "users/"+strconv.FormatUint(uint64(claims.UserID), 10), models.HaltRequest{Reason: "review"})
+	s.tick("RELIANCE", 2390)
+	if got := s.getOrder(halted, mine.ID); got.Status != models.OrderStatusPending {
+		t.Fatalf("halted user's order = %s, want PENDING", got.Status)
+	}
+	if got := s.getOrder(other, theirs.ID); got.Status != models.OrderStatusCompleted {
+		t.Fatalf("other user's order = %s, want COMPLETED", got.Status)
+	}
+}
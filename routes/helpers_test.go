@@ -0,0 +1,245 @@
+package routes_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+	"trading-platform-backend/config"
+	"trading-platform-backend/data"
+	"trading-platform-backend/middleware"
+	"trading-platform-backend/models"
+	"trading-platform-backend/repository"
+	"trading-platform-backend/routes"
+	"trading-platform-backend/services"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/go-redis/redis/v8"
+)
+
+func TestMain(m *testing.M) {
+	gin.SetMode(gin.TestMode)
+	slog.SetDefault(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	os.Exit(m.Run())
+}
+
+// testServer wires the real routes and global middleware against in-memory
+// repositories and a miniredis instance, so the suite runs fully offline
+type testServer struct {
+	t      *testing.T
+	router *gin.Engine
+	redis  *miniredis.Miniredis
+	cfg    *config.Config
+	auth   *services.AuthService
+
+	instruments *services.InstrumentService
+	prices      *services.PriceSimulator
+	candles     *services.CandleService
+	calendar    *services.MarketCalendar
+	engine      *services.ExecutionEngine
+	gtts        *services.GTTService
+	algos       *services.AlgoService
+	liquidation *services.LiquidationService
+}
+
+type serverOption func(*config.Config)
+
+// yieldingOrderRepository yields after every read, as a database round trip
+// would, so concurrent requests interleave even on a single CPU
+type yieldingOrderRepository struct {
+	repository.OrderRepository
+}
+
+func (r yieldingOrderRepository) ListByUser(ctx context.Context, userID uint) ([]models.Order, error) {
+	orders, err := r.OrderRepository.ListByUser(ctx, userID)
+	runtime.Gosched()
+	return orders, err
+}
+
+// tradingHours is the default test clock: a Tuesday during the NSE normal session
+var tradingHours = time.Date(2026, 10, 20, 10, 30, 0, 0, ist)
+
+var ist = time.FixedZone("IST", 5*3600+1800)
+
+// listUnpriced adds an active instrument with no previous close, so it has
+// no last price until it first trades
+func (s *testServer) listUnpriced(symbol string) {
+	s.t.Helper()
+	csv := "symbol,exchange,instrument_type,tick_size,lot_size\n" + symbol + ",NSE,EQ,0.05,1\n"
+	if _, err := s.instruments.LoadCSV(context.Background(), strings.NewReader(csv)); err != nil {
+		s.t.Fatalf("list %s: %v", symbol, err)
+	}
+}
+
+// setTime moves the market calendar's clock
+func (s *testServer) setTime(t time.Time) {
+	s.calendar.SetClock(func() time.Time { return t })
+}
+
+func newTestServer(t *testing.T, opts ...serverOption) *testServer {
+	t.Helper()
+
+	cfg, err := config.LoadFile("")
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	cfg.JWTSecret = "test-access-secret"
+	cfg.JWTRefreshSecret = "test-refresh-secret"
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	mr := miniredis.RunT(t)
+	redisClient := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { redisClient.Close() })
+
+	userRepository := repository.NewMemoryUserRepository()
+	authService := services.NewAuthService(userRepository, cfg)
+	instrumentService := services.NewInstrumentService(repository.NewMemoryInstrumentRepository())
+	if _, err := instrumentService.LoadCSV(context.Background(), bytes.NewReader(data.InstrumentsCSV)); err != nil {
+		t.Fatalf("load instruments: %v", err)
+	}
+	cfgManager := config.NewManager("", cfg)
+	calendar, err := services.LoadMarketCalendar(cfg.MarketConfig)
+	if err != nil {
+		t.Fatalf("load market calendar: %v", err)
+	}
+	calendar.SetClock(func() time.Time { return tradingHours })
+	orderRepository := yieldingOrderRepository{repository.NewMemoryOrderRepository()}
+	priceSimulator := services.NewPriceSimulator(instrumentService, calendar, cfg.SimulatorConfig)
+	candleService := services.NewCandleService(repository.NewMemoryCandleRepository(), instrumentService, calendar, cfg.CandleConfig)
+	priceSimulator.Subscribe(candleService.OnTick)
+	haltStore := repository.NewRedisHaltStore(redisClient)
+	// Execute synchronously so tests observe fills right after a simulator step
+	executionEngine := services.NewExecutionEngine(orderRepository, haltStore, instrumentService, priceSimulator, calendar)
+	priceSimulator.Subscribe(func(tick services.Tick) {
+		if err := executionEngine.ProcessTick(context.Background(), tick); err != nil {
+			t.Errorf("process tick: %v", err)
+		}
+	})
+	haltService := services.NewHaltService(haltStore, userRepository, orderRepository, executionEngine, instrumentService, calendar)
+	positionService := services.NewPositionService(orderRepository, priceSimulator, calendar)
+	dataService := services.NewDataService(orderRepository, positionService)
+	marginService := services.NewMarginService(orderRepository, positionService, instrumentService, priceSimulator, cfg.MarginConfig)
+	riskService := services.NewRiskService(userRepository, orderRepository, positionService, marginService, priceSimulator, cfgManager)
+	orderService := services.NewOrderService(orderRepository, executionEngine, haltService, riskService, instrumentService, priceSimulator, calendar, cfgManager)
+	gttService := services.NewGTTService(repository.NewMemoryGTTRepository(), orderService, instrumentService, priceSimulator, calendar)
+	algoService := services.NewAlgoService(repository.NewMemoryAlgoOrderRepository(), orderRepository, orderService, instrumentService, calendar)
+	liquidationService := services.NewLiquidationService(orderRepository, positionService, marginService, orderService, executionEngine, calendar, cfg.LiquidationConfig)
+	cbService := services.NewCircuitBreakerService(cfg.CircuitBreakerConfig)
+
+	r := gin.New()
+	r.Use(middleware.RequestID())
+	r.Use(middleware.Metrics())
+	r.Use(middleware.Logger())
+	r.Use(middleware.Recovery())
+	r.Use(middleware.CircuitBreaker(cbService))
+
+	// Deliberately failing endpoint used to trip the circuit breaker
+	r.GET("/test/fail", func(c *gin.Context) {
+		c.Status(http.StatusInternalServerError)
+	})
+
+	routes.SetupRoutes(r, routes.Services{
+		Auth:        authService,
+		Data:        dataService,
+		Instruments: instrumentService,
+		Orders:      orderService,
+		MarketData:  services.NewMarketDataService(instrumentService, priceSimulator, orderRepository),
+		Candles:     candleService,
+		GTTs:        gttService,
+		Algos:       algoService,
+		Halts:       haltService,
+		Margins:     marginService,
+		Calendar:    calendar,
+		RateLimits:  repository.NewRedisRateLimitStore(redisClient),
+		Config:      cfgManager,
+	})
+
+	return &testServer{t: t, router: r, redis: mr, cfg: cfg, auth: authService, instruments: instrumentService, prices: priceSimulator, candles: candleService, calendar: calendar, engine: executionEngine, gtts: gttService, algos: algoService, liquidation: liquidationService}
+}
+
+// do performs a request with an optional JSON body and bearer token
+func (s *testServer) do(method, path string, body any, token string) *httptest.ResponseRecorder {
+	s.t.Helper()
+
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			s.t.Fatalf("marshal body: %v", err)
+		}
+		reader = bytes.NewReader(payload)
+	}
+
+	req := httptest.NewRequest(method, path, reader)
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	return w
+}
+
+// signup creates an account and returns its tokens
+func (s *testServer) signup(email, password string) models.AuthResponse {
+	s.t.Helper()
+
+	w := s.do(http.MethodPost, "/api/v1/auth/signup", models.SignupRequest{Email: email, Password: password}, "")
+	if w.Code != http.StatusOK {
+		s.t.Fatalf("signup %s: status %d: %s", email, w.Code, w.Body.String())
+	}
+	return decode[models.AuthResponse](s.t, w)
+}
+
+func decode[T any](t *testing.T, w *httptest.ResponseRecorder) T {
+	t.Helper()
+
+	var v T
+	if err := json.Unmarshal(w.Body.Bytes(), &v); err != nil {
+		t.Fatalf("decode response %q: %v", w.Body.String(), err)
+	}
+	return v
+}
+
+func expectStatus(t *testing.T, w *httptest.ResponseRecorder, want int) {
+	t.Helper()
+
+	if w.Code != want {
+		t.Fatalf("status = %d, want %d (body: %s)", w.Code, want, w.Body.String())
+	}
+}
+
+func withAccessTTL(ttl time.Duration) serverOption {
+	return func(cfg *config.Config) { cfg.JWTExpiresIn = ttl }
+}
+
+func withCircuitBreaker(threshold int, reset time.Duration) serverOption {
+	return func(cfg *config.Config) {
+		cfg.CircuitBreakerConfig.ErrorThreshold = threshold
+		cfg.CircuitBreakerConfig.ResetTimeout = reset
+	}
+}
+
+// doWithHeader performs a bodyless request with a single custom header
+func (s *testServer) doWithHeader(method, path, header, value string) *httptest.ResponseRecorder {
+	s.t.Helper()
+
+	req := httptest.NewRequest(method, path, nil)
+	req.Header.Set(header, value)
+
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	return w
+}
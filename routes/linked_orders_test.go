@@ -0,0 +1,165 @@
+package routes_test
+
+import (
+	"net/http"
+	"testing"
+	"trading-platform-backend/models"
+	"trading-platform-backend/services"
+)
+
+func (s *testServer) placeLinked(path, token string, req any) models.Order {
+	s.t.Helper()
+	w := s.do(http.MethodPost, path, req, token)
+	expectStatus(s.t, w, http.StatusCreated)
+	return decode[models.Order](s.t, w)
+}
+
+// legStatuses maps each leg of a parent order to its status
+func legStatuses(order models.Order) map[string]string {
+	statuses := make(map[string]string)
+	for _, leg := range order.Legs {
+		statuses[leg.Leg] = leg.Status
+	}
+	return statuses
+}
+
+func TestBracketOrder(t *testing.T) {
+	s := newTestServer(t)
+	token := s.signup("bracket@example.com", "secret123").AccessToken
+
+	// RELIANCE last price before trading is its previous close, 2485.20
+	entry := s.placeLinked("/api/v1/orders/bracket", token, models.BracketOrderRequest{
+		Symbol: "RELIANCE", Side: "BUY", OrderType: "LIMIT", Quantity: 2, Price: 2480, TargetPrice: 2520, StopLossPrice: 2450,
+	})
+	if entry.Status != models.OrderStatusPending || entry.OrderClass != models.OrderClassBracket || entry.Leg != models.LegEntry {
+		t.Fatalf("entry = %+v", entry)
+	}
+	if legs := legStatuses(entry); len(legs) != 2 || legs[models.LegTarget] != models.OrderStatusWaiting || legs[models.LegStopLoss] != models.OrderStatusWaiting {
+		t.Fatalf("legs before the entry fills = %v, want both WAITING", legs)
+	}
+
+	s.tick("RELIANCE", 2478)
+	entry = s.getOrder(token, entry.ID)
+	if entry.Status != models.OrderStatusCompleted {
+		t.Fatalf("entry after a tick below its limit = %s, want COMPLETED", entry.Status)
+	}
+	if legs := legStatuses(entry); legs[models.LegTarget] != models.OrderStatusPending || legs[models.LegStopLoss] != models.OrderStatusTriggerPending {
+		t.Fatalf("legs after the entry fills = %v", legs)
+	}
+
+	s.tick("RELIANCE", 2521)
+	if legs := legStatuses(s.getOrder(token, entry.ID)); legs[models.LegTarget] != models.OrderStatusCompleted || legs[models.LegStopLoss] != models.OrderStatusCancelled {
+		t.Fatalf("legs after the target fills = %v, want the stop-loss cancelled", legs)
+	}
+
+	w := s.do(http.MethodGet, "/api/v1/orderbook", nil, token)
+	expectStatus(t, w, http.StatusOK)
+	book := decode[models.OrderbookResponse](t, w)
+	if len(book.Orders) != 1 || book.Orders[0].ID != entry.ID || len(book.Orders[0].Legs) != 2 {
+		t.Fatalf("orderbook = %+v, want the legs grouped under the entry", book.Orders)
+	}
+}
+
+func TestBracketCancellation(t *testing.T) {
+	s := newTestServer(t)
+	token := s.signup("bracket-cancel@example.com", "secret123").AccessToken
+
+	entry := s.placeLinked("/api/v1/orders/bracket", token, models.BracketOrderRequest{
+		Symbol: "RELIANCE", Side: "SELL", OrderType: "LIMIT", Quantity: 1, Price: 2500, TargetPrice: 2460, StopLossPrice: 2530,
+	})
+	w := s.do(http.MethodDelete, "/api/v1/orders/"+entry.ID, nil, token)
+	expectStatus(t, w, http.StatusOK)
+	cancelled := decode[models.Order](t, w)
+	if legs := legStatuses(cancelled); cancelled.Status != models.OrderStatusCancelled ||
+		legs[models.LegTarget] != models.OrderStatusCancelled || legs[models.LegStopLoss] != models.OrderStatusCancelled {
+		t.Fatalf("cancelled bracket = %s with legs %v, want everything cancelled", cancelled.Status, legs)
+	}
+	expectStatus(t, s.do(http.MethodDelete, "/api/v1/orders/"+entry.ID, nil, token), http.StatusConflict)
+
+	// Cancelling a filled entry drops its open exit legs
+	entry = s.placeLinked("/api/v1/orders/bracket", token, models.BracketOrderRequest{
+		Symbol: "RELIANCE", Side: "BUY", OrderType: "MARKET", Quantity: 1, TargetPrice: 2520, StopLossPrice: 2450,
+	})
+	if legs := legStatuses(entry); entry.Status != models.OrderStatusCompleted || legs[models.LegTarget] != models.OrderStatusPending {
+		t.Fatalf("market bracket = %s with legs %v, want the entry filled and exits active", entry.Status, legs)
+	}
+	expectStatus(t, s.do(http.MethodDelete, "/api/v1/orders/"+entry.ID, nil, token), http.StatusOK)
+	if legs := legStatuses(s.getOrder(token, entry.ID)); legs[models.LegTarget] != models.OrderStatusCancelled || legs[models.LegStopLoss] != models.OrderStatusCancelled {
+		t.Fatalf("legs after cancelling a filled bracket = %v", legs)
+	}
+
+	other := s.signup("bracket-other@example.com", "secret123").AccessToken
+	expectStatus(t, s.do(http.MethodDelete, "/api/v1/orders/"+entry.ID, nil, other), http.StatusNotFound)
+}
+
+func TestOCOOrder(t *testing.T) {
+	s := newTestServer(t)
+	token := s.signup("oco@example.com", "secret123").AccessToken
+
+	target := s.placeLinked("/api/v1/orders/oco", token, models.OCOOrderRequest{
+		Symbol: "RELIANCE", Side: "SELL", Quantity: 1, TargetPrice: 2520, StopLossPrice: 2450,
+	})
+	if target.Status != models.OrderStatusPending || len(target.Legs) != 1 || target.Legs[0].Status != models.OrderStatusTriggerPending {
+		t.Fatalf("OCO = %+v, want a pending target and a trigger pending stop-loss", target)
+	}
+
+	s.tick("RELIANCE", 2449)
+	target = s.getOrder(token, target.ID)
+	if target.Status != models.OrderStatusCancelled || target.Legs[0].Status != models.OrderStatusCompleted {
+		t.Fatalf("OCO after the stop-loss fills = %s / %s, want the target cancelled", target.Status, target.Legs[0].Status)
+	}
+
+	// Cancelling either leg cancels the pair
+	target = s.placeLinked("/api/v1/orders/oco", token, models.OCOOrderRequest{
+		Symbol: "RELIANCE", Side: "SELL", Quantity: 1, TargetPrice: 2520, StopLossPrice: 2400, TimeInForce: "GTC",
+	})
+	expectStatus(t, s.do(http.MethodDelete, "/api/v1/orders/"+target.Legs[0].ID, nil, token), http.StatusOK)
+	if target = s.getOrder(token, target.ID); target.Status != models.OrderStatusCancelled {
+		t.Fatalf("target after cancelling its stop-loss = %s, want CANCELLED", target.Status)
+	}
+}
+
+func TestLinkedOrderValidation(t *testing.T) {
+	s := newTestServer(t)
+	token := s.signup("linked-validation@example.com", "secret123").AccessToken
+	s.listUnpriced("NEWLIST")
+
+	tests := []struct {
+		name string
+		path string
+		req  any
+		code string
+	}{
+		{"buy bracket target below entry", "/api/v1/orders/bracket", models.BracketOrderRequest{
+			Symbol: "RELIANCE", Side: "BUY", OrderType: "LIMIT", Quantity: 1, Price: 2480, TargetPrice: 2470, StopLossPrice: 2450,
+		}, services.RejectInvalidLegPrice},
+		{"sell bracket stop below entry", "/api/v1/orders/bracket", models.BracketOrderRequest{
+			Symbol: "RELIANCE", Side: "SELL", OrderType: "MARKET", Quantity: 1, TargetPrice: 2460, StopLossPrice: 2470,
+		}, services.RejectInvalidTrigger},
+		{"bracket target off tick", "/api/v1/orders/bracket", models.BracketOrderRequest{
+			Symbol: "RELIANCE", Side: "BUY", OrderType: "LIMIT", Quantity: 1, Price: 2480, TargetPrice: 2520.03, StopLossPrice: 2450,
+		}, services.RejectInvalidTick},
+		{"market bracket without a last price", "/api/v1/orders/bracket", models.BracketOrderRequest{
+			Symbol: "NEWLIST", Side: "BUY", OrderType: "MARKET", Quantity: 1, TargetPrice: 120, StopLossPrice: 80,
+		}, services.RejectNoLastPrice},
+		{"sell OCO target below market", "/api/v1/orders/oco", models.OCOOrderRequest{
+			Symbol: "RELIANCE", Side: "SELL", Quantity: 1, TargetPrice: 2480, StopLossPrice: 2450,
+		}, services.RejectInvalidLegPrice},
+		{"buy OCO stop below market", "/api/v1/orders/oco", models.OCOOrderRequest{
+			Symbol: "RELIANCE", Side: "BUY", Quantity: 1, TargetPrice: 2450, StopLossPrice: 2480,
+		}, services.RejectInvalidTrigger},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := s.do(http.MethodPost, tt.path, tt.req, token)
+			expectStatus(t, w, http.StatusUnprocessableEntity)
+			if code := decode[models.ErrorResponse](t, w).Code; code != tt.code {
+				t.Fatalf("code = %q, want %q", code, tt.code)
+			}
+		})
+	}
+
+	expectStatus(t, s.do(http.MethodPost, "/api/v1/orders/bracket", models.BracketOrderRequest{
+		Symbol: "RELIANCE", Side: "BUY", OrderType: "SL", Quantity: 1, TargetPrice: 2520, StopLossPrice: 2450,
+	}, token), http.StatusBadRequest)
+}
@@ -0,0 +1,151 @@
+package routes_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+	"trading-platform-backend/models"
+)
+
+// runLiquidation moves the clock to at and runs the risk job once
+func (s *testServer) runLiquidation(at time.Time) {
+	s.t.Helper()
+	s.setTime(at)
+	if err := s.liquidation.Run(context.Background(), at); err != nil {
+		s.t.Fatalf("run liquidation: %v", err)
+	}
+}
+
+func (s *testServer) orderbook(token string) []models.Order {
+	s.t.Helper()
+	w := s.do(http.MethodGet, "/api/v1/orderbook", nil, token)
+	expectStatus(s.t, w, http.StatusOK)
+	return decode[models.OrderbookResponse](s.t, w).Orders
+}
+
+// netQuantity returns the signed position in symbol from the user's margins
+func netQuantity(m models.Margins, symbol string) int {
+	for _, sm := range m.Symbols {
+		if sm.Symbol == symbol {
+			return sm.Quantity
+		}
+	}
+	return 0
+}
+
+func TestMarginCallLiquidation(t *testing.T) {
+	s := newTestServer(t, withStartingCash(45000))
+	token := s.signup("liquidate@example.com", "secret123").AccessToken
+	healthy := s.signup("liquidate-healthy@example.com", "secret123").AccessToken
+
+	s.placeOrder(healthy, models.PlaceOrderRequest{Symbol: "RELIANCE", Side: "BUY", OrderType: "MARKET", Quantity: 1})
+	s.placeOrder(token, models.PlaceOrderRequest{Symbol: "RELIANCE", Side: "BUY", OrderType: "SL-M", Quantity: 40, TriggerPrice: 2900})
+	s.placeOrder(token, models.PlaceOrderRequest{Symbol: "RELIANCE", Side: "BUY", OrderType: "SL-M", Quantity: 60, TriggerPrice: 2900, Product: "INTRADAY"})
+	resting := s.placeOrder(token, models.PlaceOrderRequest{Symbol: "INFY", Side: "BUY", OrderType: "LIMIT", Quantity: 1, Price: 1800})
+
+	// Nothing to do while the account has no positions at risk
+	s.runLiquidation(tradingHours)
+	if got := s.getOrder(token, resting.ID); got.Status != models.OrderStatusPending {
+		t.Fatalf("resting order = %s, want PENDING", got.Status)
+	}
+
+	// Filled at 2900 while RELIANCE last trades at 2485.20: equity of 3520
+	// is below 25% of the 39763.20 used margin
+	s.tick("RELIANCE", 2900)
+	if m := s.getMargins(token); m.Equity != 3520 || !m.MarginCall {
+		t.Fatalf("margins after the loss = %+v, want a margin call", m)
+	}
+
+	// Exits respect the platform kill switch
+	admin := s.signupAdmin("liquidate-admin@example.com")
+	s.halt(admin, "platform", models.HaltRequest{Reason: "exchange connectivity"})
+	s.runLiquidation(tradingHours.Add(time.Minute))
+	if m := s.getMargins(token); netQuantity(m, "RELIANCE") != 100 {
+		t.Fatalf("margins during a platform halt = %+v, want the position kept", m)
+	}
+	expectStatus(t, s.do(http.MethodDelete, "/api/v1/admin/halts/platform", nil, admin), http.StatusOK)
+
+	s.runLiquidation(tradingHours.Add(time.Minute))
+	if got := s.getOrder(token, resting.ID); got.Status != models.OrderStatusCancelled {
+		t.Fatalf("resting order = %s, want CANCELLED", got.Status)
+	}
+	m := s.getMargins(token)
+	if len(m.Symbols) != 0 || m.UsedMargin != 0 || m.Cash != 3520 || m.MarginCall {
+		t.Fatalf("margins after liquidation = %+v, want a flat account", m)
+	}
+
+	// Each part of the position is closed as the product that opened it
+	orders := s.orderbook(token)
+	exits := map[string]int{}
+	for _, exit := range orders[:2] {
+		if exit.Side != "SELL" || exit.OrderType != "MARKET" ||
+			exit.Status != models.OrderStatusCompleted || exit.AveragePrice != 2485.2 {
+			t.Fatalf("exit order = %+v", exit)
+		}
+		exits[exit.Product] = exit.Quantity
+	}
+	if exits[models.ProductIntraday] != 60 || exits[models.ProductDelivery] != 40 {
+		t.Fatalf("exit quantities by product = %v, want 60 INTRADAY and 40 DELIVERY", exits)
+	}
+
+	// A flat account is left alone, as is one with enough equity
+	s.runLiquidation(tradingHours.Add(2 * time.Minute))
+	if got := len(s.orderbook(token)); got != len(orders) {
+		t.Fatalf("orderbook has %d orders after another run, want %d", got, len(orders))
+	}
+	if m := s.getMargins(healthy); netQuantity(m, "RELIANCE") != 1 {
+		t.Fatalf("healthy account margins = %+v, want its position kept", m)
+	}
+}
+
+func TestIntradaySquareOff(t *testing.T) {
+	s := newTestServer(t)
+	token := s.signup("square-off@example.com", "secret123").AccessToken
+
+	s.placeOrder(token, models.PlaceOrderRequest{Symbol: "RELIANCE", Side: "BUY", OrderType: "MARKET", Quantity: 10, Product: "INTRADAY"})
+	s.placeOrder(token, models.PlaceOrderRequest{Symbol: "RELIANCE", Side: "BUY", OrderType: "MARKET", Quantity: 5})
+	s.placeOrder(token, models.PlaceOrderRequest{Symbol: "INFY", Side: "SELL", OrderType: "MARKET", Quantity: 4, Product: "INTRADAY"})
+	intraday := s.placeOrder(token, models.PlaceOrderRequest{Symbol: "INFY", Side: "BUY", OrderType: "LIMIT", Quantity: 1, Price: 1800, Product: "INTRADAY"})
+	delivery := s.placeOrder(token, models.PlaceOrderRequest{Symbol: "RELIANCE", Side: "BUY", OrderType: "LIMIT", Quantity: 1, Price: 2400})
+	if delivery.Product != models.ProductDelivery {
+		t.Fatalf("product = %q, want DELIVERY by default", delivery.Product)
+	}
+
+	// Positions are squared off 10 minutes before the 15:30 close
+	day := time.Date(2026, 10, 20, 0, 0, 0, 0, ist)
+	s.runLiquidation(day.Add(15*time.Hour + 19*time.Minute))
+	if m := s.getMargins(token); netQuantity(m, "RELIANCE") != 15 || netQuantity(m, "INFY") != -4 {
+		t.Fatalf("margins before the square-off = %+v", m)
+	}
+
+	s.runLiquidation(day.Add(15*time.Hour + 20*time.Minute))
+	if m := s.getMargins(token); netQuantity(m, "RELIANCE") != 5 || netQuantity(m, "INFY") != 0 {
+		t.Fatalf("margins after the square-off = %+v, want only the delivery position", m)
+	}
+	if got := s.getOrder(token, intraday.ID); got.Status != models.OrderStatusCancelled {
+		t.Fatalf("intraday order = %s, want CANCELLED", got.Status)
+	}
+	if got := s.getOrder(token, delivery.ID); got.Status != models.OrderStatusPending {
+		t.Fatalf("delivery order = %s, want PENDING", got.Status)
+	}
+
+	orders := s.orderbook(token)
+	exits := 0
+	for _, order := range orders {
+		if order.OrderTime.Equal(day.Add(15*time.Hour + 20*time.Minute)) {
+			exits++
+			if order.Product != models.ProductIntraday || order.Status != models.OrderStatusCompleted {
+				t.Fatalf("exit order = %+v", order)
+			}
+		}
+	}
+	if exits != 2 {
+		t.Fatalf("placed %d exit orders, want 2", exits)
+	}
+
+	s.runLiquidation(day.Add(15*time.Hour + 25*time.Minute))
+	if got := len(s.orderbook(token)); got != len(orders) {
+		t.Fatalf("orderbook has %d orders after another run, want %d", got, len(orders))
+	}
+}
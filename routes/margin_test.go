@@ -0,0 +1,139 @@
+package routes_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"trading-platform-backend/config"
+	"trading-platform-backend/models"
+	"trading-platform-backend/services"
+)
+
+func withStartingCash(cash float64) serverOption {
+	return func(cfg *config.Config) { cfg.MarginConfig.StartingCash = cash }
+}
+
+func (s *testServer) getMargins(token string) models.Margins {
+	s.t.Helper()
+	w := s.do(http.MethodGet, "/api/v1/margins", nil, token)
+	expectStatus(s.t, w, http.StatusOK)
+	return decode[models.Margins](s.t, w)
+}
+
+func TestShortSellingMargin(t *testing.T) {
+	s := newTestServer(t, withStartingCash(45000))
+	token := s.signup("short@example.com", "secret123").AccessToken
+
+	if m := s.getMargins(token); m.Cash != 45000 || m.Equity != 45000 || m.AvailableMargin != 45000 || len(m.Symbols) != 0 {
+		t.Fatalf("opening margins = %+v", m)
+	}
+
+	// RELIANCE carries 12.5% VaR + 3.5% ELM on its last price, 2485.20
+	s.placeOrder(token, models.PlaceOrderRequest{Symbol: "RELIANCE", Side: "SELL", OrderType: "MARKET", Quantity: 10})
+	m := s.getMargins(token)
+	if len(m.Symbols) != 1 || m.Symbols[0].Quantity != -10 || m.Symbols[0].MarginRate != 16 || m.UsedMargin != 3976.32 {
+		t.Fatalf("margins after a short sale = %+v", m)
+	}
+
+	// A resting buy only covers the short; another sell adds exposure
+	s.placeOrder(token, models.PlaceOrderRequest{Symbol: "RELIANCE", Side: "BUY", OrderType: "LIMIT", Quantity: 5, Price: 2400})
+	if m := s.getMargins(token); m.BlockedMargin != 0 {
+		t.Fatalf("blocked margin for a covering order = %g, want 0", m.BlockedMargin)
+	}
+	s.placeOrder(token, models.PlaceOrderRequest{Symbol: "RELIANCE", Side: "SELL", OrderType: "LIMIT", Quantity: 5, Price: 2550})
+	m = s.getMargins(token)
+	if m.BlockedMargin != 1988.16 || m.AvailableMargin != m.Equity-m.UsedMargin-m.BlockedMargin {
+		t.Fatalf("margins with an open sell = %+v", m)
+	}
+
+	// INFY at 15.6% needs about 29000 for 100 shares
+	w := s.do(http.MethodPost, "/api/v1/orders", models.PlaceOrderRequest{Symbol: "INFY", Side: "BUY", OrderType: "MARKET", Quantity: 150}, token)
+	expectStatus(t, w, http.StatusUnprocessableEntity)
+	if code := decode[models.ErrorResponse](t, w).Code; code != services.RejectInsufficientMargin {
+		t.Fatalf("code = %q, want %q", code, services.RejectInsufficientMargin)
+	}
+	s.placeOrder(token, models.PlaceOrderRequest{Symbol: "INFY", Side: "BUY", OrderType: "MARKET", Quantity: 100})
+}
+
+func TestMarginCall(t *testing.T) {
+	s := newTestServer(t, withStartingCash(45000))
+	token := s.signup("margin-call@example.com", "secret123").AccessToken
+
+	stop := s.placeOrder(token, models.PlaceOrderRequest{Symbol: "RELIANCE", Side: "BUY", OrderType: "SL-M", Quantity: 100, TriggerPrice: 2900})
+	if m := s.getMargins(token); m.BlockedMargin != 39763.2 || m.AvailableMargin != 5236.8 {
+		t.Fatalf("margins with an open stop = %+v", m)
+	}
+
+	// Filled at 2900 while RELIANCE last trades at 2485.20
+	s.tick("RELIANCE", 2900)
+	if order := s.getOrder(token, stop.ID); order.Status != models.OrderStatusCompleted {
+		t.Fatalf("stop = %s, want COMPLETED", order.Status)
+	}
+	m := s.getMargins(token)
+	if m.UnrealizedPNL != -41480 || m.Equity != 3520 || m.UsedMargin != 39763.2 || m.MaintenanceMargin != 19881.6 || !m.MarginCall {
+		t.Fatalf("margins after the loss = %+v, want a margin call", m)
+	}
+
+	s.expectRejected(token, models.PlaceOrderRequest{Symbol: "INFY", Side: "BUY", OrderType: "MARKET", Quantity: 1}, services.RejectInsufficientMargin)
+	s.placeOrder(token, models.PlaceOrderRequest{Symbol: "RELIANCE", Side: "SELL", OrderType: "MARKET", Quantity: 50})
+}
+
+func TestUnpricedSymbolMargin(t *testing.T) {
+	s := newTestServer(t, withStartingCash(45000))
+	token := s.signup("unpriced-margin@example.com", "secret123").AccessToken
+	s.listUnpriced("NEWLIST")
+
+	// Without a last price each order is valued at its own price, at the
+	// default 20% rate: 20000 and then 24000 more
+	s.placeOrder(token, models.PlaceOrderRequest{Symbol: "NEWLIST", Side: "BUY", OrderType: "LIMIT", Quantity: 1000, Price: 100})
+	s.placeOrder(token, models.PlaceOrderRequest{Symbol: "NEWLIST", Side: "BUY", OrderType: "LIMIT", Quantity: 1000, Price: 120})
+	if m := s.getMargins(token); m.BlockedMargin != 44000 || m.AvailableMargin != 1000 {
+		t.Fatalf("margins with open orders on an unpriced symbol = %+v", m)
+	}
+
+	s.expectRejected(token, models.PlaceOrderRequest{Symbol: "NEWLIST", Side: "BUY", OrderType: "LIMIT", Quantity: 100, Price: 100}, services.RejectInsufficientMargin)
+	s.expectRejected(token, models.PlaceOrderRequest{Symbol: "NEWLIST", Side: "SELL", OrderType: "MARKET", Quantity: 1}, services.RejectNoLastPrice)
+}
+
+func TestConcurrentOrdersShareMargin(t *testing.T) {
+	s := newTestServer(t, withStartingCash(255000))
+	token := s.signup("concurrent@example.com", "secret123").AccessToken
+
+	// Each order blocks 27834.24, so nine of the ten fit in 255000
+	req := models.PlaceOrderRequest{Symbol: "RELIANCE", Side: "BUY", OrderType: "LIMIT", Quantity: 70, Price: 2400}
+	responses := make([]*httptest.ResponseRecorder, 10)
+	start := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := range responses {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			responses[i] = s.do(http.MethodPost, "/api/v1/orders", req, token)
+		}()
+	}
+	close(start)
+	wg.Wait()
+
+	placed, rejected := 0, 0
+	for _, w := range responses {
+		switch w.Code {
+		case http.StatusCreated:
+			placed++
+		case http.StatusUnprocessableEntity:
+			if code := decode[models.ErrorResponse](t, w).Code; code != services.RejectInsufficientMargin {
+				t.Fatalf("code = %q, want %q", code, services.RejectInsufficientMargin)
+			}
+			rejected++
+		default:
+			t.Fatalf("status = %d, body = %s", w.Code, w.Body.String())
+		}
+	}
+	if placed != 9 || rejected != 1 {
+		t.Fatalf("placed %d and rejected %d orders, want 9 and 1", placed, rejected)
+	}
+	if m := s.getMargins(token); m.BlockedMargin != 250508.16 {
+		t.Fatalf("blocked margin = %g, want 250508.16", m.BlockedMargin)
+	}
+}
@@ -0,0 +1,80 @@
+package routes_test
+
+import (
+	"net/http"
+	"testing"
+	"trading-platform-backend/models"
+)
+
+func TestQuotes(t *testing.T) {
+	s := newTestServer(t)
+	token := s.signup("quotes@example.com", "secret123").AccessToken
+
+	// Before the first tick the quote sits at the previous close
+	w := s.do(http.MethodGet, "/api/v1/quotes?symbols=tcs,INFY", nil, token)
+	expectStatus(t, w, http.StatusOK)
+	quotes := decode[models.QuotesResponse](t, w).Quotes
+	if len(quotes) != 2 || quotes[0].Symbol != "TCS" || quotes[1].Symbol != "INFY" {
+		t.Fatalf("quotes = %+v", quotes)
+	}
+	if q := quotes[0]; q.LastPrice != q.PrevClose || q.Change != 0 || q.Bid >= q.LastPrice || q.Ask <= q.LastPrice {
+		t.Fatalf("TCS quote before trading = %+v", q)
+	}
+
+	for range 5 {
+		s.prices.Step()
+	}
+	w = s.do(http.MethodGet, "/api/v1/quotes?symbols=TCS", nil, token)
+	expectStatus(t, w, http.StatusOK)
+	q := decode[models.QuotesResponse](t, w).Quotes[0]
+	if q.Volume == 0 || q.High < q.Low || q.LastPrice < q.Low || q.LastPrice > q.High {
+		t.Fatalf("TCS quote after trading = %+v", q)
+	}
+
+	expectStatus(t, s.do(http.MethodGet, "/api/v1/quotes?symbols=TCS,ACME", nil, token), http.StatusNotFound)
+	expectStatus(t, s.do(http.MethodGet, "/api/v1/quotes", nil, token), http.StatusBadRequest)
+}
+
+func TestMarketDepth(t *testing.T) {
+	s := newTestServer(t)
+	token := s.signup("depth@example.com", "secret123").AccessToken
+
+	levelAt := func(levels []models.DepthLevel, price float64) models.DepthLevel {
+		for _, level := range levels {
+			if level.Price == price {
+				return level
+			}
+		}
+		return models.DepthLevel{}
+	}
+	getDepth := func() models.MarketDepth {
+		w := s.do(http.MethodGet, "/api/v1/depth/SBIN?levels=3", nil, token)
+		expectStatus(t, w, http.StatusOK)
+		return decode[models.MarketDepth](t, w)
+	}
+	before := levelAt(getDepth().Bids, 582.00)
+
+	// Two resting bids at the same price, just below the last price
+	for _, qty := range []int{3, 4} {
+		w := s.do(http.MethodPost, "/api/v1/orders", models.PlaceOrderRequest{
+			Symbol: "SBIN", Side: "BUY", OrderType: "LIMIT", Quantity: qty, Price: 582.00,
+		}, token)
+		expectStatus(t, w, http.StatusCreated)
+	}
+
+	depth := getDepth()
+	if len(depth.Bids) != 3 || len(depth.Asks) != 3 {
+		t.Fatalf("depth levels = %d bids, %d asks", len(depth.Bids), len(depth.Asks))
+	}
+	if after := levelAt(depth.Bids, 582.00); after.Quantity != before.Quantity+7 || after.Orders != before.Orders+2 {
+		t.Fatalf("bid level 582.00 = %+v, want resting orders added to %+v", after, before)
+	}
+	for i := 1; i < len(depth.Asks); i++ {
+		if depth.Asks[i].Price <= depth.Asks[i-1].Price {
+			t.Fatalf("asks not sorted best first: %+v", depth.Asks)
+		}
+	}
+
+	expectStatus(t, s.do(http.MethodGet, "/api/v1/depth/SBIN?levels=50", nil, token), http.StatusBadRequest)
+	expectStatus(t, s.do(http.MethodGet, "/api/v1/depth/ACME", nil, token), http.StatusNotFound)
+}
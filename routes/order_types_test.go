@@ -0,0 +1,178 @@
+package routes_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"trading-platform-backend/models"
+	"trading-platform-backend/services"
+)
+
+// tick feeds a crafted trade price straight to the execution engine
+func (s *testServer) tick(symbol string, price float64) {
+	s.t.Helper()
+	if err := s.engine.ProcessTick(context.Background(), services.Tick{Symbol: symbol, Price: price, Volume: 1, Time: tradingHours}); err != nil {
+		s.t.Fatalf("process tick: %v", err)
+	}
+}
+
+func (s *testServer) placeOrder(token string, req models.PlaceOrderRequest) models.Order {
+	s.t.Helper()
+	w := s.do(http.MethodPost, "/api/v1/orders", req, token)
+	expectStatus(s.t, w, http.StatusCreated)
+	return decode[models.Order](s.t, w)
+}
+
+func (s *testServer) getOrder(token, id string) models.Order {
+	s.t.Helper()
+	w := s.do(http.MethodGet, "/api/v1/orders/"+id, nil, token)
+	expectStatus(s.t, w, http.StatusOK)
+	return decode[models.Order](s.t, w)
+}
+
+func TestOrderTypeValidation(t *testing.T) {
+	s := newTestServer(t)
+	token := s.signup("types@example.com", "secret123").AccessToken
+
+	// RELIANCE last price before trading is its previous close, 2485.20
+	tests := []struct {
+		name string
+		req  models.PlaceOrderRequest
+		code string
+	}{
+		{"market with price", models.PlaceOrderRequest{Side: "BUY", OrderType: "MARKET", Price: 2480}, services.RejectPriceNotAllowed},
+		{"limit without price", models.PlaceOrderRequest{Side: "BUY", OrderType: "LIMIT"}, services.RejectPriceRequired},
+		{"limit with trigger", models.PlaceOrderRequest{Side: "BUY", OrderType: "LIMIT", Price: 2480, TriggerPrice: 2490}, services.RejectTriggerNotAllowed},
+		{"stop-market without trigger", models.PlaceOrderRequest{Side: "SELL", OrderType: "SL-M"}, services.RejectTriggerRequired},
+		{"stop-market with price", models.PlaceOrderRequest{Side: "SELL", OrderType: "SL-M", Price: 2470, TriggerPrice: 2470}, services.RejectPriceNotAllowed},
+		{"stop-limit without price", models.PlaceOrderRequest{Side: "BUY", OrderType: "SL", TriggerPrice: 2500}, services.RejectPriceRequired},
+		{"buy stop below market", models.PlaceOrderRequest{Side: "BUY", OrderType: "SL", Price: 2480, TriggerPrice: 2480}, services.RejectInvalidTrigger},
+		{"sell stop above market", models.PlaceOrderRequest{Side: "SELL", OrderType: "SL-M", TriggerPrice: 2490}, services.RejectInvalidTrigger},
+		{"buy stop-limit below trigger", models.PlaceOrderRequest{Side: "BUY", OrderType: "SL", Price: 2495, TriggerPrice: 2500}, services.RejectInvalidTrigger},
+		{"trigger off tick", models.PlaceOrderRequest{Side: "SELL", OrderType: "SL-M", TriggerPrice: 2470.02}, services.RejectInvalidTick},
+		{"trailing stop without trail", models.PlaceOrderRequest{Side: "SELL", OrderType: "TSL"}, services.RejectTrailRequired},
+		{"trailing stop with both trails", models.PlaceOrderRequest{Side: "SELL", OrderType: "TSL", TrailAmount: 10, TrailPercent: 1}, services.RejectTrailRequired},
+		{"trailing stop with trigger", models.PlaceOrderRequest{Side: "SELL", OrderType: "TSL", TrailAmount: 10, TriggerPrice: 2470}, services.RejectTriggerNotAllowed},
+		{"limit with trail", models.PlaceOrderRequest{Side: "BUY", OrderType: "LIMIT", Price: 2480, TrailAmount: 10}, services.RejectTrailNotAllowed},
+		{"trail off tick", models.PlaceOrderRequest{Side: "SELL", OrderType: "TSL", TrailAmount: 10.02}, services.RejectInvalidTick},
+		{"trail above price", models.PlaceOrderRequest{Side: "SELL", OrderType: "TSL", TrailAmount: 2500}, services.RejectInvalidTrail},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.req.Symbol, tt.req.Quantity = "RELIANCE", 1
+			w := s.do(http.MethodPost, "/api/v1/orders", tt.req, token)
+			expectStatus(t, w, http.StatusUnprocessableEntity)
+			if code := decode[models.ErrorResponse](t, w).Code; code != tt.code {
+				t.Fatalf("code = %q, want %q", code, tt.code)
+			}
+		})
+	}
+
+	expectStatus(t, s.do(http.MethodPost, "/api/v1/orders", models.PlaceOrderRequest{
+		Symbol: "RELIANCE", Side: "BUY", OrderType: "STOP", Quantity: 1,
+	}, token), http.StatusBadRequest)
+}
+
+func TestMarketAndLimitExecution(t *testing.T) {
+	s := newTestServer(t)
+	token := s.signup("exec@example.com", "secret123").AccessToken
+
+	market := s.placeOrder(token, models.PlaceOrderRequest{Symbol: "RELIANCE", Side: "BUY", OrderType: "MARKET", Quantity: 2})
+	if market.Status != models.OrderStatusCompleted || market.FilledQuantity != 2 || market.AveragePrice != 2485.20 {
+		t.Fatalf("market order = %+v, want filled at the last price", market)
+	}
+
+	limit := s.placeOrder(token, models.PlaceOrderRequest{Symbol: "RELIANCE", Side: "SELL", OrderType: "LIMIT", Quantity: 1, Price: 2490})
+	if limit.Status != models.OrderStatusPending {
+		t.Fatalf("limit order status = %q, want PENDING", limit.Status)
+	}
+	s.tick("RELIANCE", 2489.95)
+	if got := s.getOrder(token, limit.ID); got.Status != models.OrderStatusPending {
+		t.Fatalf("limit filled below its price: %+v", got)
+	}
+	s.tick("RELIANCE", 2491)
+	if got := s.getOrder(token, limit.ID); got.Status != models.OrderStatusCompleted || got.AveragePrice != 2491 {
+		t.Fatalf("limit order = %+v, want filled at 2491", got)
+	}
+}
+
+func TestStopOrderTriggers(t *testing.T) {
+	s := newTestServer(t)
+	token := s.signup("stops@example.com", "secret123").AccessToken
+
+	stopMarket := s.placeOrder(token, models.PlaceOrderRequest{Symbol: "RELIANCE", Side: "SELL", OrderType: "SL-M", Quantity: 1, TriggerPrice: 2470})
+	stopLimit := s.placeOrder(token, models.PlaceOrderRequest{Symbol: "RELIANCE", Side: "BUY", OrderType: "SL", Quantity: 1, Price: 2502, TriggerPrice: 2500})
+	for _, o := range []models.Order{stopMarket, stopLimit} {
+		if o.Status != models.OrderStatusTriggerPending {
+			t.Fatalf("%s order status = %q, want TRIGGER_PENDING", o.OrderType, o.Status)
+		}
+	}
+
+	// Waiting stops are not shown in the order book
+	w := s.do(http.MethodGet, "/api/v1/depth/RELIANCE?levels=20", nil, token)
+	expectStatus(t, w, http.StatusOK)
+	for _, level := range decode[models.MarketDepth](t, w).Bids {
+		if level.Price == 2502 {
+			t.Fatalf("trigger-pending stop visible in depth: %+v", level)
+		}
+	}
+
+	s.tick("RELIANCE", 2475)
+	if got := s.getOrder(token, stopMarket.ID); got.Status != models.OrderStatusTriggerPending {
+		t.Fatalf("sell stop fired above its trigger: %+v", got)
+	}
+	s.tick("RELIANCE", 2469.5)
+	if got := s.getOrder(token, stopMarket.ID); got.Status != models.OrderStatusCompleted || got.AveragePrice != 2469.5 {
+		t.Fatalf("stop-market order = %+v, want filled at 2469.5", got)
+	}
+
+	// The buy stop-limit triggers above its limit, rests, then fills
+	s.tick("RELIANCE", 2505)
+	if got := s.getOrder(token, stopLimit.ID); got.Status != models.OrderStatusPending {
+		t.Fatalf("stop-limit order = %+v, want triggered and resting", got)
+	}
+	s.tick("RELIANCE", 2501)
+	if got := s.getOrder(token, stopLimit.ID); got.Status != models.OrderStatusCompleted || got.AveragePrice != 2501 {
+		t.Fatalf("stop-limit order = %+v, want filled at 2501", got)
+	}
+}
+
+func TestTrailingStop(t *testing.T) {
+	s := newTestServer(t)
+	token := s.signup("trailing@example.com", "secret123").AccessToken
+
+	sell := s.placeOrder(token, models.PlaceOrderRequest{Symbol: "RELIANCE", Side: "SELL", OrderType: "TSL", Quantity: 1, TrailAmount: 10})
+	if sell.Status != models.OrderStatusTriggerPending || sell.TriggerPrice != 2475.20 {
+		t.Fatalf("sell trailing stop = %+v, want trigger 10 below 2485.20", sell)
+	}
+
+	// The trigger follows a rise but never falls back
+	s.tick("RELIANCE", 2500)
+	if got := s.getOrder(token, sell.ID).TriggerPrice; got != 2490 {
+		t.Fatalf("trigger after a rise to 2500 = %g, want 2490", got)
+	}
+	s.tick("RELIANCE", 2495)
+	if got := s.getOrder(token, sell.ID).TriggerPrice; got != 2490 {
+		t.Fatalf("trigger after a dip to 2495 = %g, want it to stay at 2490", got)
+	}
+	s.tick("RELIANCE", 2489.5)
+	if got := s.getOrder(token, sell.ID); got.Status != models.OrderStatusCompleted || got.AveragePrice != 2489.5 {
+		t.Fatalf("sell trailing stop after the trigger trades = %+v, want filled at the market", got)
+	}
+
+	// A percentage trail rounds away from the market to the tick size
+	buy := s.placeOrder(token, models.PlaceOrderRequest{Symbol: "RELIANCE", Side: "BUY", OrderType: "TSL", Quantity: 1, TrailPercent: 1})
+	if buy.TriggerPrice != 2510.10 {
+		t.Fatalf("buy trailing stop trigger = %g, want 2510.10", buy.TriggerPrice)
+	}
+	s.tick("RELIANCE", 2480)
+	if got := s.getOrder(token, buy.ID); got.Status != models.OrderStatusTriggerPending || got.TriggerPrice != 2504.80 {
+		t.Fatalf("buy trailing stop after a fall to 2480 = %+v, want trigger 2504.80", got)
+	}
+
+	// Without a last price there is nothing to trail from
+	s.listUnpriced("NEWLIST")
+	for _, side := range []string{"BUY", "SELL"} {
+		s.expectRejected(token, models.PlaceOrderRequest{Symbol: "NEWLIST", Side: side, OrderType: "TSL", Quantity: 1, TrailAmount: 5}, services.RejectNoLastPrice)
+	}
+}
@@ -0,0 +1,131 @@
+package routes_test
+
+import (
+	"net/http"
+	"testing"
+	"trading-platform-backend/config"
+	"trading-platform-backend/models"
+	"trading-platform-backend/services"
+)
+
+func withHaltedSymbols(symbols ...string) serverOption {
+	return func(cfg *config.Config) { cfg.TradingConfig.HaltedSymbols = symbols }
+}
+
+func TestInstrumentSearch(t *testing.T) {
+	s := newTestServer(t)
+	token := s.signup("search@example.com", "secret123").AccessToken
+
+	w := s.do(http.MethodGet, "/api/v1/instruments?q=hdfc", nil, token)
+	expectStatus(t, w, http.StatusOK)
+	resp := decode[models.InstrumentsResponse](t, w)
+	if len(resp.Instruments) == 0 || resp.Instruments[0].Symbol != "HDFCBANK" {
+		t.Fatalf("search hdfc = %+v, want HDFCBANK first", resp.Instruments)
+	}
+
+	w = s.do(http.MethodGet, "/api/v1/instruments?limit=3", nil, token)
+	expectStatus(t, w, http.StatusOK)
+	if got := len(decode[models.InstrumentsResponse](t, w).Instruments); got != 3 {
+		t.Fatalf("limit=3 returned %d instruments", got)
+	}
+
+	w = s.do(http.MethodGet, "/api/v1/instruments/tcs", nil, token)
+	expectStatus(t, w, http.StatusOK)
+	if got := decode[models.Instrument](t, w); got.ISIN != "INE467B01029" {
+		t.Fatalf("TCS ISIN = %q", got.ISIN)
+	}
+
+	expectStatus(t, s.do(http.MethodGet, "/api/v1/instruments/NOPE", nil, token), http.StatusNotFound)
+	expectStatus(t, s.do(http.MethodGet, "/api/v1/instruments?limit=0", nil, token), http.StatusBadRequest)
+}
+
+func TestPlaceOrder(t *testing.T) {
+	s := newTestServer(t)
+	token := s.signup("orders@example.com", "secret123").AccessToken
+
+	w := s.do(http.MethodPost, "/api/v1/orders", models.PlaceOrderRequest{
+		Symbol: "reliance", Side: "BUY", OrderType: "LIMIT", Quantity: 5, Price: 2480,
+	}, token)
+	expectStatus(t, w, http.StatusCreated)
+	order := decode[models.Order](t, w)
+	if order.Symbol != "RELIANCE" || order.Status != models.OrderStatusPending {
+		t.Fatalf("placed order = %+v", order)
+	}
+
+	w = s.do(http.MethodGet, "/api/v1/orders/"+order.ID, nil, token)
+	expectStatus(t, w, http.StatusOK)
+
+	// Orders are private to their owner
+	other := s.signup("other@example.com", "secret123").AccessToken
+	expectStatus(t, s.do(http.MethodGet, "/api/v1/orders/"+order.ID, nil, other), http.StatusNotFound)
+}
+
+func TestPlaceOrderRejections(t *testing.T) {
+	s := newTestServer(t, withHaltedSymbols("ITC"))
+	token := s.signup("rejects@example.com", "secret123").AccessToken
+
+	tests := []struct {
+		name string
+		req  models.PlaceOrderRequest
+		code string
+	}{
+		{"unknown symbol", models.PlaceOrderRequest{Symbol: "ACME", Side: "BUY", OrderType: "LIMIT", Quantity: 1, Price: 10}, services.RejectUnknownSymbol},
+		{"halted symbol", models.PlaceOrderRequest{Symbol: "ITC", Side: "SELL", OrderType: "LIMIT", Quantity: 1, Price: 415}, services.RejectTradingHalted},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := s.do(http.MethodPost, "/api/v1/orders", tt.req, token)
+			expectStatus(t, w, http.StatusUnprocessableEntity)
+			if got := decode[models.ErrorResponse](t, w).Code; got != tt.code {
+				t.Fatalf("code = %q, want %q", got, tt.code)
+			}
+		})
+	}
+
+	w := s.do(http.MethodPost, "/api/v1/orders", models.PlaceOrderRequest{Symbol: "TCS", Side: "HOLD", OrderType: "LIMIT", Quantity: 1, Price: 10}, token)
+	expectStatus(t, w, http.StatusBadRequest)
+}
+
+func TestPositionsAndHoldings(t *testing.T) {
+	s := newTestServer(t)
+	token := s.signup("positions@example.com", "secret123").AccessToken
+
+	w := s.do(http.MethodGet, "/api/v1/positions", nil, token)
+	expectStatus(t, w, http.StatusOK)
+	if got := decode[models.PositionsResponse](t, w); got.Positions == nil || len(got.Positions) != 0 || got.PNLCard != (models.PNLCard{}) {
+		t.Fatalf("positions of a new account = %+v, want none", got)
+	}
+
+	// Bought at 2485.20 and partly sold at 2510; INFY is sold short
+	s.placeOrder(token, models.PlaceOrderRequest{Symbol: "RELIANCE", Side: "BUY", OrderType: "MARKET", Quantity: 10})
+	s.placeOrder(token, models.PlaceOrderRequest{Symbol: "RELIANCE", Side: "SELL", OrderType: "LIMIT", Quantity: 4, Price: 2500})
+	s.tick("RELIANCE", 2510)
+	s.placeOrder(token, models.PlaceOrderRequest{Symbol: "INFY", Side: "SELL", OrderType: "MARKET", Quantity: 4})
+
+	// 99.20 realized on 22338.80 still invested
+	card := models.PNLCard{TotalPNL: 99.2, TotalPNLPercent: 0.44, DayPNL: 99.2, DayPNLPercent: 0.44, RealizedPNL: 99.2}
+
+	w = s.do(http.MethodGet, "/api/v1/positions", nil, token)
+	expectStatus(t, w, http.StatusOK)
+	positions := decode[models.PositionsResponse](t, w)
+	if len(positions.Positions) != 2 || positions.PNLCard != card {
+		t.Fatalf("positions = %+v", positions)
+	}
+	if p := positions.Positions[0]; p.Symbol != "INFY" || p.PositionType != models.PositionShort || p.Quantity != 4 || p.AveragePrice != 1856.9 {
+		t.Fatalf("INFY position = %+v", p)
+	}
+	if p := positions.Positions[1]; p.Symbol != "RELIANCE" || p.PositionType != models.PositionLong || p.Quantity != 6 || p.RealizedPNL != 99.2 {
+		t.Fatalf("RELIANCE position = %+v", p)
+	}
+
+	w = s.do(http.MethodGet, "/api/v1/holdings", nil, token)
+	expectStatus(t, w, http.StatusOK)
+	holdings := decode[models.HoldingsResponse](t, w)
+	if len(holdings.Holdings) != 1 || holdings.Holdings[0].Symbol != "RELIANCE" || holdings.Holdings[0].Quantity != 6 || holdings.PNLCard != card {
+		t.Fatalf("holdings = %+v, want only the long position", holdings)
+	}
+
+	if got := decode[models.OrderbookResponse](t, s.do(http.MethodGet, "/api/v1/orderbook", nil, token)).PNLCard; got != card {
+		t.Fatalf("orderbook P&L = %+v, want %+v", got, card)
+	}
+}
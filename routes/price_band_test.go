@@ -0,0 +1,83 @@
+package routes_test
+
+import (
+	"net/http"
+	"testing"
+	"trading-platform-backend/config"
+	"trading-platform-backend/models"
+	"trading-platform-backend/services"
+)
+
+func withVolatility(volatility float64) serverOption {
+	return func(cfg *config.Config) { cfg.SimulatorConfig.Volatility = volatility }
+}
+
+func TestOrderPriceValidation(t *testing.T) {
+	s := newTestServer(t)
+	token := s.signup("bands@example.com", "secret123").AccessToken
+
+	// WIPRO: previous close 478.25, 10% band, tick 0.05 -> 430.45 to 526.05
+	tests := []struct {
+		name  string
+		price float64
+		code  string
+	}{
+		{"off tick", 478.27, services.RejectInvalidTick},
+		{"above upper band", 526.10, services.RejectPriceBand},
+		{"below lower band", 430.40, services.RejectPriceBand},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := s.do(http.MethodPost, "/api/v1/orders", models.PlaceOrderRequest{Symbol: "WIPRO", Side: "BUY", OrderType: "LIMIT", Quantity: 1, Price: tt.price}, token)
+			expectStatus(t, w, http.StatusUnprocessableEntity)
+			if code := decode[models.ErrorResponse](t, w).Code; code != tt.code {
+				t.Fatalf("code = %q, want %q", code, tt.code)
+			}
+		})
+	}
+
+	for _, price := range []float64{430.45, 526.05} {
+		w := s.do(http.MethodPost, "/api/v1/orders", models.PlaceOrderRequest{Symbol: "WIPRO", Side: "BUY", OrderType: "LIMIT", Quantity: 1, Price: price}, token)
+		expectStatus(t, w, http.StatusCreated)
+	}
+
+	w := s.do(http.MethodGet, "/api/v1/quotes?symbols=WIPRO", nil, token)
+	expectStatus(t, w, http.StatusOK)
+	if q := decode[models.QuotesResponse](t, w).Quotes[0]; q.LowerBand != 430.45 || q.UpperBand != 526.05 {
+		t.Fatalf("quote bands = %g - %g", q.LowerBand, q.UpperBand)
+	}
+}
+
+func TestCircuitHalt(t *testing.T) {
+	s := newTestServer(t, withVolatility(0.1))
+	token := s.signup("circuit@example.com", "secret123").AccessToken
+
+	// With 10% volatility per tick ITC's 10% band is hit within a few steps
+	for i := 0; i < 1000; i++ {
+		if _, halted := s.prices.HaltedUntil("ITC"); halted {
+			break
+		}
+		s.prices.Step()
+	}
+	if _, halted := s.prices.HaltedUntil("ITC"); !halted {
+		t.Fatal("ITC never hit its price band")
+	}
+
+	lower, upper, _ := s.prices.Band("ITC")
+	snapshot, _ := s.prices.Snapshot("ITC")
+	if snapshot.LastPrice != lower && snapshot.LastPrice != upper {
+		t.Fatalf("halted at %g, want a band limit (%g or %g)", snapshot.LastPrice, lower, upper)
+	}
+
+	w := s.do(http.MethodPost, "/api/v1/orders", models.PlaceOrderRequest{Symbol: "ITC", Side: "BUY", OrderType: "LIMIT", Quantity: 1, Price: snapshot.LastPrice}, token)
+	expectStatus(t, w, http.StatusUnprocessableEntity)
+	if code := decode[models.ErrorResponse](t, w).Code; code != services.RejectTradingHalted {
+		t.Fatalf("code = %q, want %q", code, services.RejectTradingHalted)
+	}
+
+	// The halt lifts once the cooling-off period has passed
+	s.setTime(tradingHours.Add(s.cfg.SimulatorConfig.CircuitHalt))
+	if _, halted := s.prices.HaltedUntil("ITC"); halted {
+		t.Fatal("ITC still halted after the cooling-off period")
+	}
+}
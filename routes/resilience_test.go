@@ -0,0 +1,100 @@
+package routes_test
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+	"trading-platform-backend/config"
+	"trading-platform-backend/models"
+)
+
+func TestLoginRateLimit(t *testing.T) {
+	s := newTestServer(t)
+	s.signup("trader@example.com", "password123")
+	policy := s.cfg.RateLimitConfig.Login
+
+	bad := models.LoginRequest{Email: "trader@example.com", Password: "wrong"}
+	for i := 0; i < policy.MaxRequests; i++ {
+		w := s.do(http.MethodPost, "/api/v1/auth/login", bad, "")
+		expectStatus(t, w, http.StatusBadRequest)
+		if remaining := w.Header().Get("X-RateLimit-Remaining"); remaining != strconv.Itoa(policy.MaxRequests-i-1) {
+			t.Errorf("attempt %d: X-RateLimit-Remaining = %q, want %d", i+1, remaining, policy.MaxRequests-i-1)
+		}
+	}
+
+	// Even correct credentials are rejected once the limit is reached
+	good := models.LoginRequest{Email: "trader@example.com", Password: "password123"}
+	w := s.do(http.MethodPost, "/api/v1/auth/login", good, "")
+	expectStatus(t, w, http.StatusTooManyRequests)
+	if w.Header().Get("Retry-After") == "" {
+		t.Error("missing Retry-After header on rate limited response")
+	}
+
+	// Other routes are not affected by the login limiter
+	expectStatus(t, s.do(http.MethodGet, "/health", nil, ""), http.StatusOK)
+
+	// Once the window expires the client may try again
+	s.redis.FastForward(policy.Window + time.Second)
+	expectStatus(t, s.do(http.MethodPost, "/api/v1/auth/login", good, ""), http.StatusOK)
+}
+
+func TestCircuitBreakerTripsAndRecovers(t *testing.T) {
+	const resetTimeout = 100 * time.Millisecond
+	s := newTestServer(t, withCircuitBreaker(3, resetTimeout))
+
+	expectStatus(t, s.do(http.MethodGet, "/health", nil, ""), http.StatusOK)
+
+	for i := 0; i < 3; i++ {
+		s.do(http.MethodGet, "/test/fail", nil, "")
+	}
+
+	// While open, even healthy routes are short-circuited
+	w := s.do(http.MethodGet, "/health", nil, "")
+	expectStatus(t, w, http.StatusServiceUnavailable)
+	if resp := decode[models.ErrorResponse](t, w); resp.Error != "Service temporarily unavailable" {
+		t.Errorf("error = %q, want %q", resp.Error, "Service temporarily unavailable")
+	}
+
+	// After the reset timeout the breaker lets probe requests through again
+	time.Sleep(resetTimeout + 50*time.Millisecond)
+	expectStatus(t, s.do(http.MethodGet, "/health", nil, ""), http.StatusOK)
+}
+
+func TestCircuitBreakerCountsSlowResponses(t *testing.T) {
+	s := newTestServer(t, withCircuitBreaker(2, time.Minute), func(cfg *config.Config) {
+		cfg.CircuitBreakerConfig.Timeout = time.Nanosecond
+	})
+
+	// Slow but successful responses are served, then trip the breaker
+	for i := 0; i < 2; i++ {
+		w := s.do(http.MethodGet, "/health", nil, "")
+		expectStatus(t, w, http.StatusOK)
+		if body := w.Body.String(); strings.Contains(body, "unavailable") {
+			t.Fatalf("served response carries the breaker error: %s", body)
+		}
+	}
+	expectStatus(t, s.do(http.MethodGet, "/health", nil, ""), http.StatusServiceUnavailable)
+}
+
+func TestRequestIDPropagation(t *testing.T) {
+	s := newTestServer(t)
+
+	w := s.doWithHeader(http.MethodGet, "/health", "X-Request-ID", "client-supplied-id")
+	if got := w.Header().Get("X-Request-ID"); got != "client-supplied-id" {
+		t.Errorf("X-Request-ID = %q, want client-supplied-id", got)
+	}
+
+	w = s.do(http.MethodGet, "/health", nil, "")
+	if w.Header().Get("X-Request-ID") == "" {
+		t.Error("expected a generated X-Request-ID")
+	}
+}
+
+func TestUnknownRoute(t *testing.T) {
+	s := newTestServer(t)
+
+	w := s.do(http.MethodGet, "/api/v1/does-not-exist", nil, "")
+	expectStatus(t, w, http.StatusNotFound)
+}
@@ -0,0 +1,121 @@
+package routes_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"trading-platform-backend/config"
+	"trading-platform-backend/models"
+	"trading-platform-backend/services"
+)
+
+func withRiskLimits(limits config.RiskLimits) serverOption {
+	return func(cfg *config.Config) { cfg.RiskConfig.Default = limits }
+}
+
+// expectRejected places an order and checks it is rejected with code
+func (s *testServer) expectRejected(token string, req models.PlaceOrderRequest, code string) {
+	s.t.Helper()
+	w := s.do(http.MethodPost, "/api/v1/orders", req, token)
+	expectStatus(s.t, w, http.StatusUnprocessableEntity)
+	if got := decode[models.ErrorResponse](s.t, w).Code; got != code {
+		s.t.Fatalf("code = %q, want %q", got, code)
+	}
+}
+
+func TestRiskOrderLimits(t *testing.T) {
+	s := newTestServer(t, withRiskLimits(config.RiskLimits{
+		MaxOrderValue:     100000,
+		MaxOrderQuantity:  50,
+		MaxPriceDeviation: 5,
+		RestrictedSymbols: []string{"TCS"},
+	}))
+	token := s.signup("risk@example.com", "secret123").AccessToken
+
+	// RELIANCE last price before trading is its previous close, 2485.20
+	tests := []struct {
+		name string
+		req  models.PlaceOrderRequest
+		code string
+	}{
+		{"quantity", models.PlaceOrderRequest{Symbol: "RELIANCE", OrderType: "MARKET", Quantity: 60}, services.RejectRiskQuantity},
+		{"value at the last price", models.PlaceOrderRequest{Symbol: "RELIANCE", OrderType: "MARKET", Quantity: 45}, services.RejectRiskOrderValue},
+		{"value at the trigger price", models.PlaceOrderRequest{Symbol: "RELIANCE", OrderType: "SL-M", Quantity: 40, TriggerPrice: 2600}, services.RejectRiskOrderValue},
+		{"fat finger", models.PlaceOrderRequest{Symbol: "RELIANCE", OrderType: "LIMIT", Quantity: 1, Price: 2300}, services.RejectRiskPriceDeviation},
+		{"restricted symbol", models.PlaceOrderRequest{Symbol: "TCS", OrderType: "MARKET", Quantity: 1}, services.RejectRiskRestricted},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.req.Side = "BUY"
+			s.expectRejected(token, tt.req, tt.code)
+		})
+	}
+
+	s.placeOrder(token, models.PlaceOrderRequest{Symbol: "RELIANCE", Side: "BUY", OrderType: "LIMIT", Quantity: 40, Price: 2400})
+
+	// Linked orders are checked as their entry or target
+	w := s.do(http.MethodPost, "/api/v1/orders/bracket", models.BracketOrderRequest{
+		Symbol: "TCS", Side: "BUY", OrderType: "MARKET", Quantity: 1, TargetPrice: 3900, StopLossPrice: 3700,
+	}, token)
+	expectStatus(t, w, http.StatusUnprocessableEntity)
+	if code := decode[models.ErrorResponse](t, w).Code; code != services.RejectRiskRestricted {
+		t.Fatalf("bracket code = %q, want %q", code, services.RejectRiskRestricted)
+	}
+}
+
+func TestRiskLimitsByRoleAndUser(t *testing.T) {
+	s := newTestServer(t, withRiskLimits(config.RiskLimits{MaxOrderQuantity: 10}), func(cfg *config.Config) {
+		cfg.RiskConfig.Roles = map[string]config.RiskLimits{models.RoleAdmin: {MaxOrderQuantity: 100}}
+		cfg.RiskConfig.Users = map[string]config.RiskLimits{"Desk@Example.com": {RestrictedSymbols: []string{"INFY"}}}
+	})
+	trader := s.signup("trader@example.com", "secret123").AccessToken
+	desk := s.signup("desk@example.com", "secret123").AccessToken
+	if _, err := s.auth.SetUserRole(context.Background(), "desk@example.com", models.RoleAdmin); err != nil {
+		t.Fatalf("set role: %v", err)
+	}
+
+	req := models.PlaceOrderRequest{Symbol: "RELIANCE", Side: "BUY", OrderType: "MARKET", Quantity: 50}
+	s.expectRejected(trader, req, services.RejectRiskQuantity)
+	s.placeOrder(desk, req)
+
+	// The user's own entry adds to the role's limits
+	req.Symbol = "INFY"
+	s.expectRejected(desk, req, services.RejectRiskRestricted)
+}
+
+func TestRiskOpenOrderAndPositionLimits(t *testing.T) {
+	s := newTestServer(t, withRiskLimits(config.RiskLimits{MaxOpenOrders: 2, MaxPositionQuantity: 10}))
+	token := s.signup("risk-position@example.com", "secret123").AccessToken
+
+	s.placeOrder(token, models.PlaceOrderRequest{Symbol: "RELIANCE", Side: "BUY", OrderType: "MARKET", Quantity: 6})
+
+	// Resting buys count towards the position as if they filled
+	resting := s.placeOrder(token, models.PlaceOrderRequest{Symbol: "RELIANCE", Side: "BUY", OrderType: "LIMIT", Quantity: 3, Price: 2400})
+	s.expectRejected(token, models.PlaceOrderRequest{Symbol: "RELIANCE", Side: "BUY", OrderType: "MARKET", Quantity: 2}, services.RejectRiskPosition)
+
+	// Selling through the long position is limited on the short side
+	s.expectRejected(token, models.PlaceOrderRequest{Symbol: "RELIANCE", Side: "SELL", OrderType: "MARKET", Quantity: 17}, services.RejectRiskPosition)
+	s.placeOrder(token, models.PlaceOrderRequest{Symbol: "RELIANCE", Side: "SELL", OrderType: "MARKET", Quantity: 16})
+
+	s.placeOrder(token, models.PlaceOrderRequest{Symbol: "INFY", Side: "BUY", OrderType: "LIMIT", Quantity: 1, Price: 1800})
+	s.expectRejected(token, models.PlaceOrderRequest{Symbol: "INFY", Side: "BUY", OrderType: "LIMIT", Quantity: 1, Price: 1800}, services.RejectRiskOpenOrders)
+
+	expectStatus(t, s.do(http.MethodDelete, "/api/v1/orders/"+resting.ID, nil, token), http.StatusOK)
+	s.placeOrder(token, models.PlaceOrderRequest{Symbol: "INFY", Side: "BUY", OrderType: "LIMIT", Quantity: 1, Price: 1800})
+}
+
+func TestRiskDailyLossLimit(t *testing.T) {
+	s := newTestServer(t, withRiskLimits(config.RiskLimits{DailyLossLimit: 100}))
+	token := s.signup("risk-loss@example.com", "secret123").AccessToken
+
+	// Buy 10 at 2500 on a stop while RELIANCE last trades at 2485.20: a 148 loss
+	stop := s.placeOrder(token, models.PlaceOrderRequest{Symbol: "RELIANCE", Side: "BUY", OrderType: "SL-M", Quantity: 10, TriggerPrice: 2500})
+	s.tick("RELIANCE", 2500)
+	if order := s.getOrder(token, stop.ID); order.Status != models.OrderStatusCompleted || order.AveragePrice != 2500 {
+		t.Fatalf("stop = %+v, want filled at 2500", order)
+	}
+
+	s.expectRejected(token, models.PlaceOrderRequest{Symbol: "INFY", Side: "BUY", OrderType: "MARKET", Quantity: 1}, services.RejectRiskDailyLoss)
+	s.expectRejected(token, models.PlaceOrderRequest{Symbol: "RELIANCE", Side: "SELL", OrderType: "MARKET", Quantity: 11}, services.RejectRiskDailyLoss)
+	s.placeOrder(token, models.PlaceOrderRequest{Symbol: "RELIANCE", Side: "SELL", OrderType: "MARKET", Quantity: 10})
+}
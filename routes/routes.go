@@ -5,15 +5,43 @@ import (
 	"trading-platform-backend/config"
 	"trading-platform-backend/handlers"
 	"trading-platform-backend/middleware"
+	"trading-platform-backend/models"
+	"trading-platform-backend/repository"
 	"trading-platform-backend/services"
 
 	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
-func SetupRoutes(r *gin.Engine, authService *services.AuthService, dataService *services.DataService, cfg *config.Config) {
+// Services bundles the dependencies the API routes are built from
+type Services struct {
+	Auth        *services.AuthService
+	Data        *services.DataService
+	Instruments *services.InstrumentService
+	Orders      *services.OrderService
+	MarketData  *services.MarketDataService
+	Candles     *services.CandleService
+	GTTs        *services.GTTService
+	Algos       *services.AlgoService
+	Halts       *services.HaltService
+	Margins     *services.MarginService
+	Calendar    *services.MarketCalendar
+	RateLimits  repository.RateLimitStore
+	Config      *config.Manager
+}
+
+func SetupRoutes(r *gin.Engine, svc Services) {
 	// Initialize handlers
-	authHandler := handlers.NewAuthHandler(authService)
-	dataHandler := handlers.NewDataHandler(dataService)
+	authHandler := handlers.NewAuthHandler(svc.Auth)
+	dataHandler := handlers.NewDataHandler(svc.Data)
+	instrumentHandler := handlers.NewInstrumentHandler(svc.Instruments)
+	orderHandler := handlers.NewOrderHandler(svc.Orders)
+	marketHandler := handlers.NewMarketHandler(svc.MarketData, svc.Calendar)
+	candleHandler := handlers.NewCandleHandler(svc.Candles, svc.Calendar)
+	gttHandler := handlers.NewGTTHandler(svc.GTTs)
+	algoHandler := handlers.NewAlgoHandler(svc.Algos)
+	haltHandler := handlers.NewHaltHandler(svc.Halts)
+	marginHandler := handlers.NewMarginHandler(svc.Margins)
 
 	// Health check endpoint (open)
 	r.GET("/health", func(c *gin.Context) {
@@ -24,6 +52,9 @@ func SetupRoutes(r *gin.Engine, authService *services.AuthService, dataService *
 		})
 	})
 
+	// Prometheus metrics endpoint (open)
+	r.GET("/metrics", gin.WrapH(promhttp.Handler()))
+
 	// API v1 routes
 	v1 := r.Group("/api/v1")
 	{
@@ -31,18 +62,61 @@ func SetupRoutes(r *gin.Engine, authService *services.AuthService, dataService *
 		auth := v1.Group("/auth")
 		{
 			auth.POST("/signup", authHandler.Signup)
-			auth.POST("/login", authHandler.Login)
+			auth.POST("/login", middleware.SimpleRateLimit(svc.RateLimits, svc.Config), authHandler.Login)
 			auth.POST("/refresh", authHandler.RefreshToken)
 		}
 
+		// Market session (no auth required)
+		v1.GET("/market/status", marketHandler.GetStatus)
+
 		// Protected routes (require JWT auth)
 		protected := v1.Group("")
-		protected.Use(middleware.AuthMiddleware(authService))
+		protected.Use(middleware.AuthMiddleware(svc.Auth))
 		{
 			// Data endpoints as specified in the document
 			protected.GET("/holdings", dataHandler.GetHoldings)
 			protected.GET("/orderbook", dataHandler.GetOrderbook)
 			protected.GET("/positions", dataHandler.GetPositions)
+			protected.GET("/margins", marginHandler.GetMargins)
+
+			// Instrument master
+			protected.GET("/instruments", instrumentHandler.ListInstruments)
+			protected.GET("/instruments/:symbol", instrumentHandler.GetInstrument)
+
+			// Market data
+			protected.GET("/quotes", marketHandler.GetQuotes)
+			protected.GET("/depth/:symbol", marketHandler.GetDepth)
+			protected.GET("/candles/:symbol", candleHandler.GetCandles)
+
+			// Order placement
+			protected.POST("/orders", orderHandler.PlaceOrder)
+			protected.POST("/orders/bracket", orderHandler.PlaceBracketOrder)
+			protected.POST("/orders/oco", orderHandler.PlaceOCOOrder)
+			protected.GET("/orders/:id", orderHandler.GetOrder)
+			protected.DELETE("/orders/:id", orderHandler.CancelOrder)
+			protected.POST("/gtt", gttHandler.CreateGTT)
+			protected.GET("/gtt", gttHandler.ListGTTs)
+			protected.GET("/gtt/:id", gttHandler.GetGTT)
+			protected.PUT("/gtt/:id", gttHandler.ModifyGTT)
+			protected.DELETE("/gtt/:id", gttHandler.CancelGTT)
+			protected.POST("/algos", algoHandler.CreateAlgoOrder)
+			protected.GET("/algos", algoHandler.ListAlgoOrders)
+			protected.GET("/algos/:id", algoHandler.GetAlgoOrder)
+			protected.DELETE("/algos/:id", algoHandler.CancelAlgoOrder)
+		}
+
+		// Administration (require the admin role)
+		admin := v1.Group("/admin")
+		admin.Use(middleware.AuthMiddleware(svc.Auth), middleware.RequireRole(models.RoleAdmin))
+		{
+			// Kill switches
+			admin.GET("/halts", haltHandler.ListHalts)
+			admin.POST("/halts/platform", haltHandler.HaltPlatform)
+			admin.DELETE("/halts/platform", haltHandler.ResumePlatform)
+			admin.POST("/halts/users/:id", haltHandler.HaltUser)
+			admin.DELETE("/halts/users/:id", haltHandler.ResumeUser)
+			admin.POST("/halts/symbols/:symbol", haltHandler.HaltSymbol)
+			admin.DELETE("/halts/symbols/:symbol", haltHandler.ResumeSymbol)
 		}
 	}
 
@@ -0,0 +1,132 @@
+package routes_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+	"trading-platform-backend/models"
+	"trading-platform-backend/services"
+)
+
+func TestImmediateOrders(t *testing.T) {
+	s := newTestServer(t)
+	token := s.signup("ioc@example.com", "secret123").AccessToken
+
+	// The market maker quotes 1500 shares of RELIANCE across the default depth
+	tests := []struct {
+		name   string
+		req    models.PlaceOrderRequest
+		status string
+		filled int
+	}{
+		{"IOC fills what is quoted", models.PlaceOrderRequest{Side: "BUY", OrderType: "MARKET", Quantity: 2000, TimeInForce: "IOC"}, models.OrderStatusCancelled, 1500},
+		{"IOC within depth", models.PlaceOrderRequest{Side: "SELL", OrderType: "MARKET", Quantity: 40, TimeInForce: "IOC"}, models.OrderStatusCompleted, 40},
+		{"IOC limit away from market", models.PlaceOrderRequest{Side: "BUY", OrderType: "LIMIT", Price: 2400, Quantity: 5, TimeInForce: "IOC"}, models.OrderStatusCancelled, 0},
+		{"FOK larger than depth", models.PlaceOrderRequest{Side: "BUY", OrderType: "MARKET", Quantity: 2000, TimeInForce: "FOK"}, models.OrderStatusCancelled, 0},
+		{"FOK within depth", models.PlaceOrderRequest{Side: "BUY", OrderType: "LIMIT", Price: 2490, Quantity: 1500, TimeInForce: "FOK"}, models.OrderStatusCompleted, 1500},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.req.Symbol = "RELIANCE"
+			order := s.placeOrder(token, tt.req)
+			if order.Status != tt.status || order.FilledQuantity != tt.filled {
+				t.Fatalf("order = %s with %d filled, want %s with %d", order.Status, order.FilledQuantity, tt.status, tt.filled)
+			}
+		})
+	}
+
+	// Without a last price there is no quote to fill against
+	s.listUnpriced("NEWLIST")
+	for _, tif := range []string{"IOC", "FOK"} {
+		s.expectRejected(token, models.PlaceOrderRequest{Symbol: "NEWLIST", Side: "BUY", OrderType: "LIMIT", Price: 100, Quantity: 1, TimeInForce: tif}, services.RejectNoLastPrice)
+	}
+}
+
+func TestTimeInForceValidation(t *testing.T) {
+	s := newTestServer(t)
+	token := s.signup("tif@example.com", "secret123").AccessToken
+
+	tests := []struct {
+		name string
+		req  models.PlaceOrderRequest
+		code string
+	}{
+		{"IOC stop", models.PlaceOrderRequest{Side: "SELL", OrderType: "SL-M", TriggerPrice: 2470, TimeInForce: "IOC"}, services.RejectInvalidTimeInForce},
+		{"GTC market", models.PlaceOrderRequest{Side: "BUY", OrderType: "MARKET", TimeInForce: "GTC"}, services.RejectInvalidTimeInForce},
+		{"GTD without date", models.PlaceOrderRequest{Side: "BUY", OrderType: "LIMIT", Price: 2400, TimeInForce: "GTD"}, services.RejectInvalidExpiry},
+		{"GTD in the past", models.PlaceOrderRequest{Side: "BUY", OrderType: "LIMIT", Price: 2400, TimeInForce: "GTD", ExpireDate: "2026-10-19"}, services.RejectInvalidExpiry},
+		{"GTD too far", models.PlaceOrderRequest{Side: "BUY", OrderType: "LIMIT", Price: 2400, TimeInForce: "GTD", ExpireDate: "2028-01-01"}, services.RejectInvalidExpiry},
+		{"date without GTD", models.PlaceOrderRequest{Side: "BUY", OrderType: "LIMIT", Price: 2400, ExpireDate: "2026-10-23"}, services.RejectInvalidExpiry},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.req.Symbol, tt.req.Quantity = "RELIANCE", 1
+			w := s.do(http.MethodPost, "/api/v1/orders", tt.req, token)
+			expectStatus(t, w, http.StatusUnprocessableEntity)
+			if code := decode[models.ErrorResponse](t, w).Code; code != tt.code {
+				t.Fatalf("code = %q, want %q", code, tt.code)
+			}
+		})
+	}
+
+	// Immediate orders need a live market
+	s.setTime(time.Date(2026, 10, 20, 9, 5, 0, 0, ist))
+	w := s.do(http.MethodPost, "/api/v1/orders", models.PlaceOrderRequest{Symbol: "RELIANCE", Side: "BUY", OrderType: "MARKET", Quantity: 1, TimeInForce: "IOC"}, token)
+	expectStatus(t, w, http.StatusUnprocessableEntity)
+}
+
+func TestOrderExpiry(t *testing.T) {
+	s := newTestServer(t)
+	token := s.signup("expiry@example.com", "secret123").AccessToken
+	resting := models.PlaceOrderRequest{Symbol: "RELIANCE", Side: "BUY", OrderType: "LIMIT", Quantity: 1, Price: 2400}
+
+	day := s.placeOrder(token, resting)
+	gtc := resting
+	gtc.TimeInForce = "GTC"
+	gtcOrder := s.placeOrder(token, gtc)
+	gtd := resting
+	gtd.TimeInForce, gtd.ExpireDate = "GTD", "2026-10-22"
+	gtdOrder := s.placeOrder(token, gtd)
+
+	if day.ExpiresAt == nil || !day.ExpiresAt.Equal(time.Date(2026, 10, 20, 16, 0, 0, 0, ist)) {
+		t.Fatalf("DAY order expires at %v, want today's close", day.ExpiresAt)
+	}
+	if gtcOrder.ExpiresAt != nil {
+		t.Fatalf("GTC order expires at %v", gtcOrder.ExpiresAt)
+	}
+
+	expire := func(at time.Time) {
+		t.Helper()
+		if _, err := s.engine.ExpireOrders(context.Background(), at); err != nil {
+			t.Fatalf("expire orders: %v", err)
+		}
+	}
+	statuses := func() map[string]string {
+		t.Helper()
+		w := s.do(http.MethodGet, "/api/v1/orderbook", nil, token)
+		expectStatus(t, w, http.StatusOK)
+		byID := map[string]string{}
+		for _, o := range decode[models.OrderbookResponse](t, w).Orders {
+			byID[o.ID] = o.Status
+		}
+		return byID
+	}
+
+	expire(time.Date(2026, 10, 20, 15, 59, 0, 0, ist))
+	if got := statuses()[day.ID]; got != models.OrderStatusPending {
+		t.Fatalf("DAY order before close = %s", got)
+	}
+
+	expire(time.Date(2026, 10, 20, 16, 0, 0, 0, ist))
+	got := statuses()
+	if got[day.ID] != models.OrderStatusExpired || got[gtdOrder.ID] != models.OrderStatusPending {
+		t.Fatalf("after first close: DAY %s, GTD %s", got[day.ID], got[gtdOrder.ID])
+	}
+
+	expire(time.Date(2026, 10, 22, 16, 0, 0, 0, ist))
+	got = statuses()
+	if got[gtdOrder.ID] != models.OrderStatusExpired || got[gtcOrder.ID] != models.OrderStatusPending {
+		t.Fatalf("after GTD date: GTD %s, GTC %s", got[gtdOrder.ID], got[gtcOrder.ID])
+	}
+}
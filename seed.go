@@ -0,0 +1,105 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+	"trading-platform-backend/data"
+	"trading-platform-backend/models"
+	"trading-platform-backend/repository"
+	"trading-platform-backend/services"
+)
+
+// demoUsers are created by the seed command, each with the demo order history
+var demoUsers = []string{"demo@example.com", "trader@example.com"}
+
+func runSeed(args []string) {
+	flags := flag.NewFlagSet("seed", flag.ExitOnError)
+	password := flags.String("password", "demo1234", "password for the demo users")
+	instrumentsFile := flags.String("instruments", "", "instrument master CSV (defaults to the bundled list)")
+	flags.Parse(args)
+
+	cfg, _ := loadConfig()
+	db := openDatabase(cfg)
+	ctx := context.Background()
+
+	users := repository.NewGormUserRepository(db)
+	orders := repository.NewGormOrderRepository(db)
+	authService := services.NewAuthService(users, cfg)
+
+	var source io.Reader = bytes.NewReader(data.InstrumentsCSV)
+	if *instrumentsFile != "" {
+		f, err := os.Open(*instrumentsFile)
+		if err != nil {
+			fatal("Failed to open instruments file", err)
+		}
+		defer f.Close()
+		source = f
+	}
+	instrumentService := services.NewInstrumentService(repository.NewGormInstrumentRepository(db))
+	loaded, err := instrumentService.LoadCSV(ctx, source)
+	if err != nil {
+		fatal("Failed to load instruments", err)
+	}
+	fmt.Printf("Loaded %d instruments\n", loaded)
+
+	for _, email := range demoUsers {
+		if _, err := users.GetByEmail(ctx, email); err == nil {
+			fmt.Printf("User %s already exists, skipping\n", email)
+			continue
+		} else if !errors.Is(err, repository.ErrNotFound) {
+			fatal("Failed to look up user", err)
+		}
+
+		user, err := authService.CreateUser(ctx, email, *password)
+		if err != nil {
+			fatal("Failed to create demo user", err)
+		}
+
+		for _, order := range demoOrders(user.ID, time.Now()) {
+			if err := orders.Create(ctx, &order); err != nil {
+				fatal("Failed to create demo order", err)
+			}
+		}
+		fmt.Printf("Created user %s (password %q) with demo orders\n", email, *password)
+	}
+}
+
+// demoOrders is a small order history spanning the common order states. Every
+// order is final so nothing executes after seeding, and the fills net to a
+// long RELIANCE, TCS and ITC position.
+func demoOrders(userID uint, now time.Time) []models.Order {
+	after := func(placed time.Time, d time.Duration) *time.Time {
+		t := placed.Add(d)
+		return &t
+	}
+
+	yesterday := now.Add(-24 * time.Hour)
+	orders := []models.Order{
+		{Symbol: "RELIANCE", Side: models.SideBuy, OrderType: models.OrderTypeLimit, Quantity: 10, Price: 2450.50, Status: models.OrderStatusCompleted, OrderTime: now.Add(-2 * time.Hour)},
+		{Symbol: "TCS", Side: models.SideBuy, OrderType: models.OrderTypeLimit, Quantity: 5, Price: 3790.00, Status: models.OrderStatusCompleted, OrderTime: now.Add(-4 * time.Hour)},
+		{Symbol: "TCS", Side: models.SideSell, OrderType: models.OrderTypeLimit, Quantity: 3, Price: 3825.00, Status: models.OrderStatusCompleted, OrderTime: now.Add(-1 * time.Hour)},
+		{Symbol: "HDFCBANK", Side: models.SideBuy, OrderType: models.OrderTypeLimit, Quantity: 5, Price: 1680.25, Status: models.OrderStatusExpired, OrderTime: yesterday, ExpiresAt: after(yesterday, 6*time.Hour)},
+		{Symbol: "INFY", Side: models.SideBuy, OrderType: models.OrderTypeLimit, Quantity: 8, Price: 1840.00, Status: models.OrderStatusCancelled, OrderTime: now.Add(-45 * time.Minute)},
+		{Symbol: "ITC", Side: models.SideBuy, OrderType: models.OrderTypeLimit, Quantity: 12, Price: 415.75, Status: models.OrderStatusCompleted, OrderTime: now.Add(-3 * time.Hour)},
+	}
+
+	for i := range orders {
+		orders[i].ID = services.NewOrderID()
+		orders[i].UserID = userID
+		orders[i].TimeInForce = models.TimeInForceDay
+		orders[i].OrderClass = models.OrderClassRegular
+		orders[i].Product = models.ProductDelivery
+		if orders[i].Status == models.OrderStatusCompleted {
+			orders[i].FilledQuantity = orders[i].Quantity
+			orders[i].AveragePrice = orders[i].Price
+			orders[i].ExecutedTime = after(orders[i].OrderTime, 2*time.Minute)
+		}
+	}
+	return orders
+}
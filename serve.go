@@ -0,0 +1,184 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+	"trading-platform-backend/config"
+	"trading-platform-backend/database"
+	"trading-platform-backend/metrics"
+	"trading-platform-backend/middleware"
+	"trading-platform-backend/repository"
+	"trading-platform-backend/routes"
+	"trading-platform-backend/services"
+	"trading-platform-backend/tracing"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+)
+
+// serve starts the HTTP API and blocks until SIGINT or SIGTERM
+func serve() {
+	cfg, configPath := loadConfig()
+	slog.Info("Effective configuration", "config", cfg, "file", configPath)
+	cfgManager := config.NewManager(configPath, cfg)
+
+	// Initialize tracing before any instrumented client is created
+	shutdownTracing, err := tracing.Init(context.Background(), cfg.TracingConfig, cfg.Environment)
+	if err != nil {
+		fatal("Failed to initialize tracing", err)
+	}
+
+	// Initialize database
+	db := openDatabase(cfg)
+
+	// Initialize Redis
+	redisClient, err := database.InitializeRedis(cfg.RedisURL, cfg.RedisPassword)
+	if err != nil {
+		fatal("Failed to connect to Redis", err)
+	}
+
+	// Expose connection pool statistics
+	sqlDB, err := db.DB()
+	if err != nil {
+		fatal("Failed to access database pool", err)
+	}
+	metrics.RegisterDBStats(sqlDB, "postgres")
+	metrics.RegisterRedisPoolStats(redisClient)
+
+	// Initialize services
+	userRepository := repository.NewGormUserRepository(db)
+	authService := services.NewAuthService(userRepository, cfg)
+	instrumentService := services.NewInstrumentService(repository.NewGormInstrumentRepository(db))
+	if err := instrumentService.Refresh(context.Background()); err != nil {
+		fatal("Failed to load instruments", err)
+	}
+	if len(instrumentService.List()) == 0 {
+		slog.Warn("Instrument master is empty; run the seed command to load it")
+	}
+	calendar, err := services.LoadMarketCalendar(cfg.MarketConfig)
+	if err != nil {
+		fatal("Failed to load market calendar", err)
+	}
+	priceSimulator := services.NewPriceSimulator(instrumentService, calendar, cfg.SimulatorConfig)
+	orderRepository := repository.NewGormOrderRepository(db)
+	marketDataService := services.NewMarketDataService(instrumentService, priceSimulator, orderRepository)
+	candleService := services.NewCandleService(repository.NewGormCandleRepository(db), instrumentService, calendar, cfg.CandleConfig)
+	priceSimulator.Subscribe(candleService.OnTick)
+	haltStore := repository.NewRedisHaltStore(redisClient)
+	executionEngine := services.NewExecutionEngine(orderRepository, haltStore, instrumentService, priceSimulator, calendar)
+	priceSimulator.Subscribe(executionEngine.OnTick)
+	haltService := services.NewHaltService(haltStore, userRepository, orderRepository, executionEngine, instrumentService, calendar)
+	positionService := services.NewPositionService(orderRepository, priceSimulator, calendar)
+	dataService := services.NewDataService(orderRepository, positionService)
+	marginService := services.NewMarginService(orderRepository, positionService, instrumentService, priceSimulator, cfg.MarginConfig)
+	riskService := services.NewRiskService(userRepository, orderRepository, positionService, marginService, priceSimulator, cfgManager)
+	orderService := services.NewOrderService(orderRepository, executionEngine, haltService, riskService, instrumentService, priceSimulator, calendar, cfgManager)
+	gttService := services.NewGTTService(repository.NewGormGTTRepository(db), orderService, instrumentService, priceSimulator, calendar)
+	priceSimulator.Subscribe(gttService.OnTick)
+	algoService := services.NewAlgoService(repository.NewGormAlgoOrderRepository(db), orderRepository, orderService, instrumentService, calendar)
+	liquidationService := services.NewLiquidationService(orderRepository, positionService, marginService, orderService, executionEngine, calendar, cfg.LiquidationConfig)
+	rateLimitStore := repository.NewRedisRateLimitStore(redisClient)
+	circuitBreakerService := services.NewCircuitBreakerService(cfg.CircuitBreakerConfig)
+
+	// Apply safe-to-change settings on SIGHUP or config file change
+	cfgManager.OnReload(func(c *config.Config) {
+		circuitBreakerService.UpdateConfig(c.CircuitBreakerConfig)
+	})
+	watchCtx, stopWatch := context.WithCancel(context.Background())
+	defer stopWatch()
+	go cfgManager.Watch(watchCtx)
+
+	// Drive simulated market prices, order execution, GTT triggers, algo
+	// orders, liquidations and candle persistence until shutdown
+	go priceSimulator.Start(watchCtx)
+	go executionEngine.Start(watchCtx)
+	go gttService.Start(watchCtx)
+	go algoService.Start(watchCtx)
+	go liquidationService.Start(watchCtx)
+	candlesDone := make(chan struct{})
+	go func() {
+		candleService.Start(watchCtx)
+		close(candlesDone)
+	}()
+
+	// Set Gin mode
+	if cfg.Environment == "production" {
+		gin.SetMode(gin.ReleaseMode)
+	}
+
+	// Initialize Gin router (logging and recovery are provided by our middleware)
+	r := gin.New()
+	r.Use(otelgin.Middleware(cfg.TracingConfig.ServiceName))
+	r.Use(middleware.RequestID())
+
+	// CORS middleware
+	r.Use(middleware.CORS(cfg.CORSConfig))
+
+	// Global middleware
+	r.Use(middleware.Metrics())
+	r.Use(middleware.Logger())
+	r.Use(middleware.Recovery())
+	r.Use(middleware.CircuitBreaker(circuitBreakerService))
+
+	// Routes
+	routes.SetupRoutes(r, routes.Services{
+		Auth:        authService,
+		Data:        dataService,
+		Instruments: instrumentService,
+		Orders:      orderService,
+		MarketData:  marketDataService,
+		Candles:     candleService,
+		GTTs:        gttService,
+		Algos:       algoService,
+		Halts:       haltService,
+		Margins:     marginService,
+		Calendar:    calendar,
+		RateLimits:  rateLimitStore,
+		Config:      cfgManager,
+	})
+
+	// Start server
+	port := os.Getenv("PORT")
+	if port == "" {
+		port = "8080"
+	}
+
+	srv := &http.Server{
+		Addr:    ":" + port,
+		Handler: r,
+	}
+
+	go func() {
+		slog.Info("Server starting", "port", port, "environment", cfg.Environment)
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			fatal("Failed to start server", err)
+		}
+	}()
+
+	// Wait for interrupt signal, then drain in-flight requests and flush spans
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	<-quit
+
+	slog.Info("Shutting down server")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := srv.Shutdown(ctx); err != nil {
+		slog.Error("Server forced to shutdown", "error", err)
+	}
+
+	// Stop background workers; the candle service flushes its open bars on exit
+	stopWatch()
+	<-candlesDone
+
+	if err := shutdownTracing(ctx); err != nil {
+		slog.Error("Failed to flush traces", "error", err)
+	}
+}
@@ -0,0 +1,434 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sync"
+	"time"
+	"trading-platform-backend/logger"
+	"trading-platform-backend/models"
+	"trading-platform-backend/repository"
+)
+
+// algoStepInterval is how often active algo orders are worked
+const algoStepInterval = time.Second
+
+// RejectInvalidAlgo rejects algo order parameters that do not fit the strategy
+const RejectInvalidAlgo = "INVALID_ALGO"
+
+var (
+	// ErrAlgoNotFound is returned when an algo order does not exist or belongs to another user
+	ErrAlgoNotFound = errors.New("algo order not found")
+	// ErrAlgoNotActive is returned when cancelling an algo order that already ended
+	ErrAlgoNotActive = errors.New("algo order is no longer active")
+)
+
+// intradayVolumeProfile is the typical share of a day's volume traded in each
+// 15-minute bucket of the normal session: heavy at the open and close, quiet
+// around midday
+var intradayVolumeProfile = []float64{
+	9.0, 6.5, 5.5, 4.8, 4.3, 4.0, 3.7, 3.5, 3.3, 3.2, 3.1, 3.0, 3.0,
+	3.0, 3.1, 3.2, 3.3, 3.5, 3.7, 4.0, 4.3, 4.8, 5.5, 6.5, 8.0,
+}
+
+// AlgoService works algo orders: it slices each parent into regular child
+// orders placed through the order service, tracks the parent's progress from
+// its children and cancels them together
+type AlgoService struct {
+	algos       repository.AlgoOrderRepository
+	orderRepo   repository.OrderRepository
+	orders      *OrderService
+	instruments *InstrumentService
+	calendar    *MarketCalendar
+
+	// process serializes work so a slice is never placed twice
+	process sync.Mutex
+}
+
+func NewAlgoService(algos repository.AlgoOrderRepository, orderRepo repository.OrderRepository, orders *OrderService, instruments *InstrumentService, calendar *MarketCalendar) *AlgoService {
+	return &AlgoService{
+		algos:       algos,
+		orderRepo:   orderRepo,
+		orders:      orders,
+		instruments: instruments,
+		calendar:    calendar,
+	}
+}
+
+// Create validates and stores a new algo order and places its first slice
+// if its window has started
+func (s *AlgoService) Create(ctx context.Context, userID uint, req models.AlgoOrderRequest) (*models.AlgoOrder, error) {
+	instrument, err := s.orders.validate(models.PlaceOrderRequest{
+		Symbol: req.Symbol, Side: req.Side, OrderType: sliceOrderType(req.Price),
+		Quantity: req.Quantity, Price: req.Price, TimeInForce: models.TimeInForceDay,
+	})
+	if err != nil {
+		return nil, err
+	}
+	start, end, slices, err := s.validate(req, instrument)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.calendar.Now()
+	algo := &models.AlgoOrder{
+		ID:              newID("ALG"),
+		UserID:          userID,
+		Strategy:        req.Strategy,
+		Symbol:          instrument.Symbol,
+		Side:            req.Side,
+		Quantity:        req.Quantity,
+		Price:           req.Price,
+		DisplayQuantity: req.DisplayQuantity,
+		Slices:          slices,
+		StartTime:       start,
+		EndTime:         end,
+		Status:          models.AlgoStatusActive,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+
+	s.process.Lock()
+	defer s.process.Unlock()
+
+	if err := s.algos.Create(ctx, algo); err != nil {
+		return nil, err
+	}
+	if err := s.work(ctx, algo, now); err != nil {
+		return nil, err
+	}
+	return algo, nil
+}
+
+// validate checks the strategy's parameters and returns the order's window
+// and slice count
+func (s *AlgoService) validate(req models.AlgoOrderRequest, instrument models.Instrument) (start, end time.Time, slices int, err error) {
+	now := s.calendar.Now()
+	sessionStart, sessionEnd := s.calendar.NormalSessionOn(now)
+	if s.calendar.AlwaysOpen() {
+		// The market never closes, so the window may run up to a day ahead
+		sessionStart, sessionEnd = now, now.Add(24*time.Hour)
+	}
+
+	start, end = now, sessionEnd
+	if req.StartTime != nil && req.StartTime.After(start) {
+		start = *req.StartTime
+	}
+	if start.Before(sessionStart) {
+		start = sessionStart
+	}
+	if req.EndTime != nil {
+		end = *req.EndTime
+	}
+
+	switch req.Strategy {
+	case models.AlgoIceberg:
+		switch {
+		case req.Price == 0:
+			return start, end, 0, rejectOrder(RejectInvalidAlgo, "ICEBERG orders need a limit price")
+		case req.DisplayQuantity == 0 || req.DisplayQuantity >= req.Quantity:
+			return start, end, 0, rejectOrder(RejectInvalidAlgo, "display_quantity must be set and below quantity")
+		case req.DisplayQuantity%instrument.LotSize != 0:
+			return start, end, 0, rejectOrder(RejectInvalidLotSize, "display_quantity must be a multiple of the lot size %d", instrument.LotSize)
+		case req.Slices != 0:
+			return start, end, 0, rejectOrder(RejectInvalidAlgo, "slices only apply to TWAP and VWAP orders")
+		}
+	default:
+		switch {
+		case req.EndTime == nil:
+			return start, end, 0, rejectOrder(RejectInvalidAlgo, "%s orders need an end_time", req.Strategy)
+		case req.DisplayQuantity != 0:
+			return start, end, 0, rejectOrder(RejectInvalidAlgo, "display_quantity only applies to ICEBERG orders")
+		}
+		slices = req.Slices
+		if slices == 0 {
+			slices = max(1, int(end.Sub(start)/time.Minute))
+		}
+		// Every slice must be at least one lot
+		slices = min(slices, req.Quantity/instrument.LotSize)
+	}
+
+	if !end.After(start) || end.After(sessionEnd) {
+		return start, end, 0, rejectOrder(RejectInvalidAlgo, "the window must end after it starts and no later than %s",
+			sessionEnd.In(s.calendar.Location()).Format("15:04"))
+	}
+	return start, end, slices, nil
+}
+
+// Get returns one of the user's algo orders with its child orders and live progress
+func (s *AlgoService) Get(ctx context.Context, userID uint, id string) (*models.AlgoOrder, error) {
+	algo, err := s.get(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.refresh(ctx, algo); err != nil {
+		return nil, err
+	}
+	return algo, nil
+}
+
+// List returns the user's algo orders, newest first
+func (s *AlgoService) List(ctx context.Context, userID uint) ([]models.AlgoOrder, error) {
+	algos, err := s.algos.ListByUser(ctx, userID)
+	if algos == nil {
+		algos = []models.AlgoOrder{}
+	}
+	for i := range algos {
+		algos[i].RemainingQuantity = algos[i].Quantity - algos[i].FilledQuantity
+	}
+	return algos, err
+}
+
+// Cancel stops an active algo order and cancels its open child orders
+func (s *AlgoService) Cancel(ctx context.Context, userID uint, id string) (*models.AlgoOrder, error) {
+	s.process.Lock()
+	defer s.process.Unlock()
+
+	algo, err := s.get(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if algo.Status != models.AlgoStatusActive {
+		return nil, ErrAlgoNotActive
+	}
+	if err := s.cancelChildren(ctx, algo); err != nil {
+		return nil, err
+	}
+	if _, err := s.refresh(ctx, algo); err != nil {
+		return nil, err
+	}
+	algo.Status = models.AlgoStatusCancelled
+	algo.UpdatedAt = s.calendar.Now()
+	if err := s.algos.Update(ctx, algo); err != nil {
+		return nil, err
+	}
+	return algo, nil
+}
+
+func (s *AlgoService) get(ctx context.Context, userID uint, id string) (*models.AlgoOrder, error) {
+	algo, err := s.algos.GetByID(ctx, id)
+	if errors.Is(err, repository.ErrNotFound) || (err == nil && algo.UserID != userID) {
+		return nil, ErrAlgoNotFound
+	}
+	return algo, err
+}
+
+// Start works active algo orders until ctx is cancelled
+func (s *AlgoService) Start(ctx context.Context) {
+	ticker := time.NewTicker(algoStepInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.Run(ctx, s.calendar.Now()); err != nil {
+				logger.FromContext(ctx).Error("Failed to work algo orders", "error", err)
+			}
+		}
+	}
+}
+
+// Run works every active algo order once
+func (s *AlgoService) Run(ctx context.Context, now time.Time) error {
+	s.process.Lock()
+	defer s.process.Unlock()
+
+	algos, err := s.algos.ListActive(ctx)
+	if err != nil {
+		return err
+	}
+	for i := range algos {
+		if err := s.work(ctx, &algos[i], now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// finalSliceRejections are the slice rejections that retrying cannot fix, as
+// every slice of the algo order repeats them. Any other rejection, such as a
+// halt or a risk limit, is recorded on the algo order and retried on the next
+// step.
+var finalSliceRejections = map[string]bool{
+	RejectUnknownSymbol:  true,
+	RejectInvalidLotSize: true,
+	RejectInvalidTick:    true,
+}
+
+// work brings an active algo order up to date: it completes once filled,
+// expires when its window closes, and otherwise places the next slice once
+// it is due
+func (s *AlgoService) work(ctx context.Context, algo *models.AlgoOrder, now time.Time) error {
+	before := *algo
+	open, err := s.refresh(ctx, algo)
+	if err != nil {
+		return err
+	}
+
+	if algo.FilledQuantity < algo.Quantity && !now.Before(algo.StartTime) && now.Before(algo.EndTime) {
+		if quantity := s.nextSlice(algo, open, now); quantity > 0 {
+			_, err := s.orders.placeOrder(ctx, algo.UserID, models.PlaceOrderRequest{
+				Symbol:      algo.Symbol,
+				Side:        algo.Side,
+				OrderType:   sliceOrderType(algo.Price),
+				Quantity:    quantity,
+				Price:       algo.Price,
+				TimeInForce: models.TimeInForceDay,
+			}, algo.ID)
+
+			var orderErr *OrderError
+			switch {
+			case errors.As(err, &orderErr):
+				algo.Message = orderErr.Code + ": " + orderErr.Message
+				if finalSliceRejections[orderErr.Code] {
+					algo.Status = models.AlgoStatusRejected
+				} else {
+					logger.FromContext(ctx).Info("Algo slice rejected; retrying on the next step", "algo_id", algo.ID,
+						"user_id", algo.UserID, "code", orderErr.Code, "reason", orderErr.Message)
+				}
+			case err != nil:
+				return err
+			default:
+				algo.Message = ""
+			}
+			if open, err = s.refresh(ctx, algo); err != nil {
+				return err
+			}
+		}
+	}
+
+	switch {
+	case algo.Status != models.AlgoStatusActive:
+	case algo.FilledQuantity >= algo.Quantity:
+		algo.Status = models.AlgoStatusCompleted
+	case !now.Before(algo.EndTime):
+		algo.Status = models.AlgoStatusExpired
+	}
+	if algo.Status != models.AlgoStatusActive && open > 0 {
+		if err := s.cancelChildren(ctx, algo); err != nil {
+			return err
+		}
+		if _, err := s.refresh(ctx, algo); err != nil {
+			return err
+		}
+	}
+
+	if algo.Status == before.Status && algo.FilledQuantity == before.FilledQuantity && algo.Message == before.Message {
+		return nil
+	}
+	algo.UpdatedAt = now
+	if algo.Status != models.AlgoStatusActive {
+		logger.FromContext(ctx).Info("Algo order finished", "algo_id", algo.ID, "user_id", algo.UserID, "strategy", algo.Strategy, "status", algo.Status, "filled_quantity", algo.FilledQuantity)
+	}
+	return s.algos.Update(ctx, algo)
+}
+
+// refresh loads the algo order's children and recomputes its progress from
+// them, returning the quantity still open in the market
+func (s *AlgoService) refresh(ctx context.Context, algo *models.AlgoOrder) (int, error) {
+	children, err := s.orderRepo.ListByAlgo(ctx, algo.ID)
+	if err != nil {
+		return 0, err
+	}
+
+	filled, open, notional := 0, 0, 0.0
+	for _, child := range children {
+		filled += child.FilledQuantity
+		notional += child.AveragePrice * float64(child.FilledQuantity)
+		if child.IsOpen() {
+			open += child.Quantity - child.FilledQuantity
+		}
+	}
+	algo.FilledQuantity = filled
+	algo.AveragePrice = 0
+	if filled > 0 {
+		algo.AveragePrice = math.Round(notional/float64(filled)*100) / 100
+	}
+	algo.RemainingQuantity = algo.Quantity - filled
+	algo.Orders = children
+	return open, nil
+}
+
+// nextSlice returns the quantity of the slice to place now, or 0 if none is
+// due. An iceberg shows a new slice once the previous one filled; TWAP and
+// VWAP top the market up to the quantity scheduled so far.
+func (s *AlgoService) nextSlice(algo *models.AlgoOrder, open int, now time.Time) int {
+	unplaced := algo.Quantity - algo.FilledQuantity - open
+	if algo.Strategy == models.AlgoIceberg {
+		if open > 0 {
+			return 0
+		}
+		return min(algo.DisplayQuantity, unplaced)
+	}
+
+	lot := 1
+	if instrument, ok := s.instruments.Get(algo.Symbol); ok {
+		lot = instrument.LotSize
+	}
+	due := s.scheduled(algo, now) - algo.FilledQuantity - open
+	return min(due/lot*lot, unplaced)
+}
+
+// scheduled returns the quantity a TWAP or VWAP order should have placed by
+// now. Slices are released at the start of each equal part of the window:
+// TWAP gives every slice the same share, VWAP the share of the day's volume
+// typically traded during its part of the window.
+func (s *AlgoService) scheduled(algo *models.AlgoOrder, now time.Time) int {
+	interval := algo.EndTime.Sub(algo.StartTime) / time.Duration(algo.Slices)
+	released := int(now.Sub(algo.StartTime)/interval) + 1
+	if released >= algo.Slices {
+		return algo.Quantity
+	}
+
+	fraction := float64(released) / float64(algo.Slices)
+	if algo.Strategy == models.AlgoVWAP {
+		from, to := s.volumeShare(algo.StartTime), s.volumeShare(algo.EndTime)
+		if to > from {
+			fraction = (s.volumeShare(algo.StartTime.Add(time.Duration(released)*interval)) - from) / (to - from)
+		}
+	}
+	return int(float64(algo.Quantity) * fraction)
+}
+
+// volumeShare returns the share of a day's volume typically traded in the
+// normal session up to t
+func (s *AlgoService) volumeShare(t time.Time) float64 {
+	start, _ := s.calendar.NormalSessionOn(t)
+	buckets := t.Sub(start).Minutes() / 15
+
+	total, share := 0.0, 0.0
+	for i, weight := range intradayVolumeProfile {
+		total += weight
+		share += weight * math.Max(0, math.Min(1, buckets-float64(i)))
+	}
+	return share / total
+}
+
+// cancelChildren cancels the algo order's open child orders
+func (s *AlgoService) cancelChildren(ctx context.Context, algo *models.AlgoOrder) error {
+	children, err := s.orderRepo.ListByAlgo(ctx, algo.ID)
+	if err != nil {
+		return err
+	}
+	for _, child := range children {
+		if !child.IsOpen() {
+			continue
+		}
+		// A child that filled in the meantime has nothing left to cancel
+		if _, err := s.orders.engine.Cancel(ctx, child.ID); err != nil && !errors.Is(err, ErrOrderNotCancellable) {
+			return err
+		}
+	}
+	return nil
+}
+
+// sliceOrderType is the order type of an algo order's slices
+func sliceOrderType(price float64) string {
+	if price > 0 {
+		return models.OrderTypeLimit
+	}
+	return models.OrderTypeMarket
+}
@@ -1,27 +1,27 @@
 package services
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"time"
 	"trading-platform-backend/config"
 	"trading-platform-backend/models"
+	"trading-platform-backend/repository"
 
-	"github.com/go-redis/redis/v8"
 	"github.com/golang-jwt/jwt/v5"
 	"golang.org/x/crypto/bcrypt"
-	"gorm.io/gorm"
 )
 
 type AuthService struct {
-	db          *gorm.DB
-	redisClient *redis.Client
-	config      *config.Config
+	users  repository.UserRepository
+	config *config.Config
 }
 
 type Claims struct {
 	UserID uint   `json:"user_id"`
 	Email  string `json:"email"`
+	Role   string `json:"role"`
 	jwt.RegisteredClaims
 }
 
@@ -32,65 +32,177 @@ type RefreshClaims struct {
 	jwt.RegisteredClaims
 }
 
-func NewAuthService(db *gorm.DB, redisClient *redis.Client, cfg *config.Config) *AuthService {
+func NewAuthService(users repository.UserRepository, cfg *config.Config) *AuthService {
 	return &AuthService{
-		db:          db,
-		redisClient: redisClient,
-		config:      cfg,
+		users:  users,
+		config: cfg,
 	}
 }
 
-// GetRedisClient returns the Redis client for rate limiting
-func (s *AuthService) GetRedisClient() *redis.Client {
-	return s.redisClient
+// ErrAccountDisabled is returned when a disabled user tries to authenticate
+var ErrAccountDisabled = errors.New("account disabled")
+
+func (s *AuthService) Signup(ctx context.Context, req models.SignupRequest) (*models.AuthResponse, error) {
+	user, err := s.CreateUser(ctx, req.Email, req.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	// Generate tokens
+	accessToken, err := s.generateAccessToken(user)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, err := s.generateRefreshToken(user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.AuthResponse{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int(s.config.JWTExpiresIn.Seconds()),
+	}, nil
 }
 
-func (s *AuthService) Signup(req models.SignupRequest) (*models.AuthResponse, error) {
+// CreateUser registers an account with a bcrypt-hashed password
+func (s *AuthService) CreateUser(ctx context.Context, email, password string) (*models.User, error) {
 	// Check if user already exists
-	var existingUser models.User
-	if err := s.db.Where("email = ?", req.Email).First(&existingUser).Error; err == nil {
+	if _, err := s.users.GetByEmail(ctx, email); err == nil {
 		return nil, errors.New("user already exists")
+	} else if !errors.Is(err, repository.ErrNotFound) {
+		return nil, err
 	}
 
 	// Hash password
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
 	if err != nil {
 		return nil, err
 	}
 
 	// Create user
 	user := models.User{
-		Email:    req.Email,
+		Email:    email,
 		Password: string(hashedPassword),
+		Role:     models.RoleTrader,
 	}
 
-	if err := s.db.Create(&user).Error; err != nil {
+	if err := s.users.Create(ctx, &user); err != nil {
+		if errors.Is(err, repository.ErrDuplicate) {
+			return nil, errors.New("user already exists")
+		}
 		return nil, err
 	}
 
-	// Generate tokens
-	accessToken, err := s.generateAccessToken(user.ID, user.Email)
+	return &user, nil
+}
+
+// SetUserDisabled blocks or re-enables login and token refresh for a user.
+// Access tokens already issued stay valid until they expire.
+func (s *AuthService) SetUserDisabled(ctx context.Context, email string, disabled bool) (*models.User, error) {
+	user, err := s.users.GetByEmail(ctx, email)
 	if err != nil {
 		return nil, err
 	}
 
-	refreshToken, err := s.generateRefreshToken(user.ID)
+	if disabled && user.DisabledAt == nil {
+		now := time.Now()
+		user.DisabledAt = &now
+	} else if !disabled {
+		user.DisabledAt = nil
+	}
+
+	if err := s.users.Update(ctx, user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// SetUserRole changes a user's role, which selects their risk limits
+func (s *AuthService) SetUserRole(ctx context.Context, email, role string) (*models.User, error) {
+	if role != models.RoleTrader && role != models.RoleAdmin {
+		return nil, fmt.Errorf("unknown role %q (expected %s or %s)", role, models.RoleTrader, models.RoleAdmin)
+	}
+	user, err := s.users.GetByEmail(ctx, email)
 	if err != nil {
 		return nil, err
 	}
 
-	return &models.AuthResponse{
-		AccessToken:  accessToken,
-		RefreshToken: refreshToken,
-		ExpiresIn:    int(s.config.JWTExpiresIn.Seconds()),
-	}, nil
+	user.Role = role
+	if err := s.users.Update(ctx, user); err != nil {
+		return nil, err
+	}
+	return user, nil
 }
 
-func (s *AuthService) Login(req models.LoginRequest) (*models.AuthResponse, error) {
+// ResetPassword replaces a user's password
+func (s *AuthService) ResetPassword(ctx context.Context, email, password string) error {
+	user, err := s.users.GetByEmail(ctx, email)
+	if err != nil {
+		return err
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+
+	user.Password = string(hashedPassword)
+	return s.users.Update(ctx, user)
+}
+
+// TokenInspection describes a decoded JWT and whether it verified
+type TokenInspection struct {
+	Kind      string         `json:"kind"` // access, refresh or unknown
+	Valid     bool           `json:"valid"`
+	Error     string         `json:"error,omitempty"`
+	Header    map[string]any `json:"header"`
+	Claims    map[string]any `json:"claims"`
+	ExpiresAt *time.Time     `json:"expires_at,omitempty"`
+}
+
+// InspectToken decodes a token and verifies it against the access and
+// refresh secrets, reporting which one (if any) signed it
+func (s *AuthService) InspectToken(tokenString string) (*TokenInspection, error) {
+	parsed, _, err := jwt.NewParser().ParseUnverified(tokenString, jwt.MapClaims{})
+	if err != nil {
+		return nil, fmt.Errorf("malformed token: %v", err)
+	}
+
+	inspection := &TokenInspection{
+		Kind:   "unknown",
+		Header: parsed.Header,
+		Claims: parsed.Claims.(jwt.MapClaims),
+	}
+	if exp, err := parsed.Claims.GetExpirationTime(); err == nil && exp != nil {
+		inspection.ExpiresAt = &exp.Time
+	}
+
+	if _, err := s.ValidateToken(tokenString); err == nil {
+		inspection.Kind, inspection.Valid = "access", true
+		return inspection, nil
+	} else if !errors.Is(err, jwt.ErrTokenSignatureInvalid) {
+		inspection.Kind, inspection.Error = "access", err.Error()
+		return inspection, nil
+	}
+
+	if _, err := s.validateRefreshToken(tokenString); err == nil {
+		inspection.Kind, inspection.Valid = "refresh", true
+	} else if !errors.Is(err, jwt.ErrTokenSignatureInvalid) {
+		inspection.Kind, inspection.Error = "refresh", err.Error()
+	} else {
+		inspection.Error = "signature does not match the configured access or refresh secret"
+	}
+
+	return inspection, nil
+}
+
+func (s *AuthService) Login(ctx context.Context, req models.LoginRequest) (*models.AuthResponse, error) {
 	// Find user
-	var user models.User
-	if err := s.db.Where("email = ?", req.Email).First(&user).Error; err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
+	user, err := s.users.GetByEmail(ctx, req.Email)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
 			return nil, errors.New("invalid credentials")
 		}
 		return nil, err
@@ -100,9 +212,12 @@ func (s *AuthService) Login(req models.LoginRequest) (*models.AuthResponse, erro
 	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.Password)); err != nil {
 		return nil, errors.New("invalid credentials")
 	}
+	if user.DisabledAt != nil {
+		return nil, ErrAccountDisabled
+	}
 
 	// Generate tokens
-	accessToken, err := s.generateAccessToken(user.ID, user.Email)
+	accessToken, err := s.generateAccessToken(user)
 	if err != nil {
 		return nil, err
 	}
@@ -139,17 +254,20 @@ func (s *AuthService) ValidateToken(tokenString string) (*Claims, error) {
 	return nil, errors.New("invalid token")
 }
 
-func (s *AuthService) generateAccessToken(userID uint, email string) (string, error) {
+// generateAccessToken issues an access token carrying the user's role, so
+// role changes take effect once the user's current token expires
+func (s *AuthService) generateAccessToken(user *models.User) (string, error) {
 	now := time.Now()
 
 	claims := &Claims{
-		UserID: userID,
-		Email:  email,
+		UserID: user.ID,
+		Email:  user.Email,
+		Role:   user.Role,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(now.Add(s.config.JWTExpiresIn)),
 			IssuedAt:  jwt.NewNumericDate(now),
 			NotBefore: jwt.NewNumericDate(now),
-			Subject:   fmt.Sprintf("%d", userID),
+			Subject:   fmt.Sprintf("%d", user.ID),
 			Issuer:    "trading-platform",
 		},
 	}
@@ -188,7 +306,7 @@ func (s *AuthService) validateRefreshToken(refreshToken string) (*RefreshClaims,
 	})
 
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse refresh token: %v", err)
+		return nil, fmt.Errorf("failed to parse refresh token: %w", err)
 	}
 
 	// Check if token is valid and extract claims
@@ -203,7 +321,7 @@ func (s *AuthService) validateRefreshToken(refreshToken string) (*RefreshClaims,
 	return nil, errors.New("invalid refresh token")
 }
 
-func (s *AuthService) RefreshToken(refreshToken string) (*models.AuthResponse, error) {
+func (s *AuthService) RefreshToken(ctx context.Context, refreshToken string) (*models.AuthResponse, error) {
 	// Validate the refresh token
 	claims, err := s.validateRefreshToken(refreshToken)
 	if err != nil {
@@ -211,13 +329,16 @@ func (s *AuthService) RefreshToken(refreshToken string) (*models.AuthResponse, e
 	}
 
 	// Get user from database
-	var user models.User
-	if err := s.db.First(&user, claims.UserID).Error; err != nil {
+	user, err := s.users.GetByID(ctx, claims.UserID)
+	if err != nil {
 		return nil, errors.New("user not found")
 	}
+	if user.DisabledAt != nil {
+		return nil, ErrAccountDisabled
+	}
 
 	// Generate new access token
-	accessToken, err := s.generateAccessToken(claims.UserID, user.Email)
+	accessToken, err := s.generateAccessToken(user)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate access token: %v", err)
 	}
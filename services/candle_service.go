@@ -0,0 +1,395 @@
+package services
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"trading-platform-backend/config"
+	"trading-platform-backend/logger"
+	"trading-platform-backend/models"
+	"trading-platform-backend/repository"
+)
+
+// CandleInterval is a supported bar size
+type CandleInterval struct {
+	Name     string
+	Duration time.Duration
+}
+
+// CandleIntervals lists the bar sizes built from ticks, smallest first
+var CandleIntervals = []CandleInterval{
+	{"1m", time.Minute},
+	{"5m", 5 * time.Minute},
+	{"15m", 15 * time.Minute},
+	{"1h", time.Hour},
+	{"1d", 24 * time.Hour},
+}
+
+// MaxCandlesPerRequest caps the bars returned by a single query
+const MaxCandlesPerRequest = 5000
+
+var (
+	ErrUnknownInterval     = errors.New("unknown candle interval")
+	ErrCandleRangeTooLarge = errors.New("candle range too large")
+)
+
+// LookupCandleInterval resolves an interval name such as "5m"
+func LookupCandleInterval(name string) (CandleInterval, bool) {
+	for _, interval := range CandleIntervals {
+		if interval.Name == name {
+			return interval, true
+		}
+	}
+	return CandleInterval{}, false
+}
+
+// BucketStart returns the open time of the bar containing t. Bars are aligned
+// to midnight in loc, so daily bars follow the exchange's trading date.
+func (i CandleInterval) BucketStart(t time.Time, loc *time.Location) time.Time {
+	_, offset := t.In(loc).Zone()
+	shift := time.Duration(offset) * time.Second
+	return t.Add(shift).Truncate(i.Duration).Add(-shift).UTC()
+}
+
+type seriesKey struct {
+	symbol   string
+	interval string
+}
+
+type barKey struct {
+	seriesKey
+	openTime int64
+}
+
+// CandleService aggregates price ticks into OHLCV candles. Bars are built in
+// memory and written to the repository every flush interval; queries merge
+// unflushed bars so charts are always current.
+type CandleService struct {
+	repo        repository.CandleRepository
+	instruments *InstrumentService
+	location    *time.Location
+	config      config.CandleConfig
+
+	mu      sync.Mutex
+	live    map[seriesKey]*models.Candle
+	pending map[barKey]models.Candle
+}
+
+func NewCandleService(repo repository.CandleRepository, instruments *InstrumentService, calendar *MarketCalendar, cfg config.CandleConfig) *CandleService {
+	return &CandleService{
+		repo:        repo,
+		instruments: instruments,
+		location:    calendar.Location(),
+		config:      cfg,
+		live:        make(map[seriesKey]*models.Candle),
+		pending:     make(map[barKey]models.Candle),
+	}
+}
+
+// OnTick folds a tick into the current bar of every interval. It is meant to
+// be registered with PriceSimulator.Subscribe.
+func (s *CandleService) OnTick(tick Tick) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, interval := range CandleIntervals {
+		key := seriesKey{tick.Symbol, interval.Name}
+		openTime := interval.BucketStart(tick.Time, s.location)
+
+		bar, ok := s.live[key]
+		if !ok {
+			// The first tick since start continues the bar already stored
+			// for its bucket, so the next flush does not overwrite it
+			bar, ok = s.storedBar(key, openTime, interval.Duration)
+		}
+		if !ok || !bar.OpenTime.Equal(openTime) {
+			bar = &models.Candle{
+				Symbol:   tick.Symbol,
+				Interval: interval.Name,
+				OpenTime: openTime,
+				Open:     tick.Price,
+				High:     tick.Price,
+				Low:      tick.Price,
+			}
+			s.live[key] = bar
+		}
+
+		bar.High = max(bar.High, tick.Price)
+		bar.Low = min(bar.Low, tick.Price)
+		bar.Close = tick.Price
+		bar.Volume += int64(tick.Volume)
+		s.pending[barKey{key, openTime.UnixNano()}] = *bar
+	}
+}
+
+// storedBar loads the bar opened at openTime from the repository, if any
+func (s *CandleService) storedBar(key seriesKey, openTime time.Time, duration time.Duration) (*models.Candle, bool) {
+	ctx := context.Background()
+	candles, err := s.repo.List(ctx, key.symbol, key.interval, openTime, openTime.Add(duration))
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to load the current candle", "symbol", key.symbol, "interval", key.interval, "error", err)
+		return nil, false
+	}
+	if len(candles) == 0 {
+		return nil, false
+	}
+	return &candles[0], true
+}
+
+// Flush writes bars changed since the last flush to the repository
+func (s *CandleService) Flush(ctx context.Context) error {
+	s.mu.Lock()
+	pending := s.pending
+	s.pending = make(map[barKey]models.Candle)
+	s.mu.Unlock()
+
+	if len(pending) == 0 {
+		return nil
+	}
+
+	candles := make([]models.Candle, 0, len(pending))
+	for _, candle := range pending {
+		candles = append(candles, candle)
+	}
+	if err := s.repo.Upsert(ctx, candles); err != nil {
+		// Keep the bars for the next attempt unless a newer version arrived meanwhile
+		s.mu.Lock()
+		for key, candle := range pending {
+			if _, ok := s.pending[key]; !ok {
+				s.pending[key] = candle
+			}
+		}
+		s.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// Prune deletes candles older than the configured retention of their interval
+func (s *CandleService) Prune(ctx context.Context, now time.Time) (int64, error) {
+	var total int64
+	for _, interval := range CandleIntervals {
+		retention := s.retention(interval)
+		if retention <= 0 {
+			continue
+		}
+		deleted, err := s.repo.DeleteBefore(ctx, interval.Name, now.Add(-retention))
+		if err != nil {
+			return total, err
+		}
+		total += deleted
+	}
+	return total, nil
+}
+
+func (s *CandleService) retention(interval CandleInterval) time.Duration {
+	switch {
+	case interval.Duration < time.Hour:
+		return s.config.MinuteRetention
+	case interval.Duration < 24*time.Hour:
+		return s.config.HourlyRetention
+	default:
+		return s.config.DailyRetention
+	}
+}
+
+// Start flushes bars every flush interval and prunes expired candles hourly
+// until ctx is cancelled, then performs a final flush
+func (s *CandleService) Start(ctx context.Context) {
+	flush := time.NewTicker(s.config.FlushInterval)
+	defer flush.Stop()
+	prune := time.NewTicker(time.Hour)
+	defer prune.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			flushCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			if err := s.Flush(flushCtx); err != nil {
+				logger.FromContext(ctx).Error("Failed to flush candles on shutdown", "error", err)
+			}
+			cancel()
+			return
+		case <-flush.C:
+			if err := s.Flush(ctx); err != nil {
+				logger.FromContext(ctx).Error("Failed to flush candles", "error", err)
+			}
+		case now := <-prune.C:
+			if deleted, err := s.Prune(ctx, now); err != nil {
+				logger.FromContext(ctx).Error("Failed to prune candles", "error", err)
+			} else if deleted > 0 {
+				logger.FromContext(ctx).Info("Pruned expired candles", "deleted", deleted)
+			}
+		}
+	}
+}
+
+// GetCandles returns the symbol's bars with from <= open time < to, oldest
+// first, including bars not yet flushed to the repository
+func (s *CandleService) GetCandles(ctx context.Context, symbol, intervalName string, from, to time.Time) (*models.CandlesResponse, error) {
+	instrument, ok := s.instruments.Get(symbol)
+	if !ok {
+		return nil, fmt.Errorf("%w %q", ErrUnknownSymbol, symbol)
+	}
+	interval, ok := LookupCandleInterval(intervalName)
+	if !ok {
+		return nil, fmt.Errorf("%w %q", ErrUnknownInterval, intervalName)
+	}
+	if to.Sub(from)/interval.Duration > MaxCandlesPerRequest {
+		return nil, fmt.Errorf("%w: at most %d %s candles per request", ErrCandleRangeTooLarge, MaxCandlesPerRequest, interval.Name)
+	}
+
+	candles, err := s.repo.List(ctx, instrument.Symbol, interval.Name, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	// Overlay bars that changed since the last flush
+	s.mu.Lock()
+	var unflushed []models.Candle
+	for key, candle := range s.pending {
+		if key.symbol == instrument.Symbol && key.interval == interval.Name &&
+			!candle.OpenTime.Before(from) && candle.OpenTime.Before(to) {
+			unflushed = append(unflushed, candle)
+		}
+	}
+	s.mu.Unlock()
+
+	candles = mergeCandles(candles, unflushed)
+	if candles == nil {
+		candles = []models.Candle{}
+	}
+	return &models.CandlesResponse{Symbol: instrument.Symbol, Interval: interval.Name, Candles: candles}, nil
+}
+
+// mergeCandles overlays newer bars on stored ones by open time, keeping order
+func mergeCandles(stored, newer []models.Candle) []models.Candle {
+	if len(newer) == 0 {
+		return stored
+	}
+
+	byTime := make(map[int64]int, len(stored))
+	for i, candle := range stored {
+		byTime[candle.OpenTime.UnixNano()] = i
+	}
+	for _, candle := range newer {
+		if i, ok := byTime[candle.OpenTime.UnixNano()]; ok {
+			stored[i] = candle
+		} else {
+			stored = append(stored, candle)
+		}
+	}
+	sort.Slice(stored, func(i, j int) bool { return stored[i].OpenTime.Before(stored[j].OpenTime) })
+	return stored
+}
+
+// ImportCSV backfills candles from CSV and returns the number imported
+func (s *CandleService) ImportCSV(ctx context.Context, r io.Reader) (int, error) {
+	candles, err := ParseCandlesCSV(r, s.location)
+	if err != nil {
+		return 0, err
+	}
+	for _, candle := range candles {
+		if _, ok := s.instruments.Get(candle.Symbol); !ok {
+			return 0, fmt.Errorf("%w %q", ErrUnknownSymbol, candle.Symbol)
+		}
+	}
+	if err := s.repo.Upsert(ctx, candles); err != nil {
+		return 0, err
+	}
+	return len(candles), nil
+}
+
+// ParseCandlesCSV reads candles from CSV with a header row containing symbol,
+// interval, open_time (RFC 3339), open, high, low, close and volume. Open
+// times must fall on an interval boundary in loc.
+func ParseCandlesCSV(r io.Reader, loc *time.Location) ([]models.Candle, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("read header: %w", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	required := []string{"symbol", "interval", "open_time", "open", "high", "low", "close", "volume"}
+	for _, name := range required {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("missing required column %q", name)
+		}
+	}
+
+	var candles []models.Candle
+	var problems []string
+
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		field := func(name string) string {
+			if i := columns[name]; i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		price := func(name string) (float64, bool) {
+			v, err := strconv.ParseFloat(field(name), 64)
+			return v, err == nil && v > 0
+		}
+
+		candle := models.Candle{
+			Symbol:   strings.ToUpper(field("symbol")),
+			Interval: field("interval"),
+		}
+		interval, intervalOK := LookupCandleInterval(candle.Interval)
+		openTime, timeErr := time.Parse(time.RFC3339, field("open_time"))
+		open, openOK := price("open")
+		high, highOK := price("high")
+		low, lowOK := price("low")
+		closePrice, closeOK := price("close")
+		volume, volumeErr := strconv.ParseInt(field("volume"), 10, 64)
+
+		switch {
+		case candle.Symbol == "":
+			problems = append(problems, fmt.Sprintf("line %d: symbol is required", line))
+		case !intervalOK:
+			problems = append(problems, fmt.Sprintf("line %d: unknown interval %q", line, candle.Interval))
+		case timeErr != nil:
+			problems = append(problems, fmt.Sprintf("line %d: invalid open_time %q", line, field("open_time")))
+		case !interval.BucketStart(openTime, loc).Equal(openTime):
+			problems = append(problems, fmt.Sprintf("line %d: open_time %s is not on a %s boundary", line, field("open_time"), interval.Name))
+		case !openOK || !highOK || !lowOK || !closeOK:
+			problems = append(problems, fmt.Sprintf("line %d: prices must be positive numbers", line))
+		case high < max(open, closePrice, low) || low > min(open, closePrice):
+			problems = append(problems, fmt.Sprintf("line %d: high/low do not bound open/close", line))
+		case volumeErr != nil || volume < 0:
+			problems = append(problems, fmt.Sprintf("line %d: invalid volume %q", line, field("volume")))
+		default:
+			candle.OpenTime = openTime.UTC()
+			candle.Open, candle.High, candle.Low, candle.Close = open, high, low, closePrice
+			candle.Volume = volume
+			candles = append(candles, candle)
+		}
+	}
+
+	if len(problems) > 0 {
+		return nil, fmt.Errorf("invalid candles file:\n  %s", strings.Join(problems, "\n  "))
+	}
+	return candles, nil
+}
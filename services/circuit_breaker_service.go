@@ -2,42 +2,71 @@
 package services
 
 import (
+	"sync"
 	"time"
+	"trading-platform-backend/config"
+	"trading-platform-backend/metrics"
 
 	"github.com/sony/gobreaker"
 )
 
 type CircuitBreakerService struct {
+	mu       sync.Mutex
+	config   config.CircuitBreakerConfig
 	breakers map[string]*gobreaker.CircuitBreaker
 }
 
-func NewCircuitBreakerService() *CircuitBreakerService {
+func NewCircuitBreakerService(cfg config.CircuitBreakerConfig) *CircuitBreakerService {
 	return &CircuitBreakerService{
+		config:   cfg,
 		breakers: make(map[string]*gobreaker.CircuitBreaker),
 	}
 }
 
+// UpdateConfig applies new thresholds. Existing breakers are replaced, so
+// their counts and state start fresh under the new settings.
+func (s *CircuitBreakerService) UpdateConfig(cfg config.CircuitBreakerConfig) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.config = cfg
+	s.breakers = make(map[string]*gobreaker.CircuitBreaker)
+}
+
+// Timeout returns how long a request may take before it counts as a failure
+func (s *CircuitBreakerService) Timeout() time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.config.Timeout
+}
+
 func (s *CircuitBreakerService) GetBreaker(name string) *gobreaker.CircuitBreaker {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	if breaker, exists := s.breakers[name]; exists {
 		return breaker
 	}
 
-	// Create new circuit breaker with default settings
+	// Create new circuit breaker from the configured thresholds
+	cfg := s.config
 	settings := gobreaker.Settings{
 		Name:        name,
-		MaxRequests: 3,
-		Interval:    time.Second * 10,
-		Timeout:     time.Second * 30,
+		MaxRequests: uint32(cfg.HalfOpenRequests),
+		Interval:    cfg.Interval,
+		Timeout:     cfg.ResetTimeout,
 		ReadyToTrip: func(counts gobreaker.Counts) bool {
 			failureRatio := float64(counts.TotalFailures) / float64(counts.Requests)
-			return counts.Requests >= 3 && failureRatio >= 0.6
+			return counts.TotalFailures >= uint32(cfg.ErrorThreshold) && failureRatio >= cfg.FailureRatio
 		},
 		OnStateChange: func(name string, from gobreaker.State, to gobreaker.State) {
-			// Log state changes
+			metrics.CircuitBreakerState.WithLabelValues(name).Set(float64(to))
 		},
 	}
 
 	breaker := gobreaker.NewCircuitBreaker(settings)
+	metrics.CircuitBreakerState.WithLabelValues(name).Set(float64(breaker.State()))
 	s.breakers[name] = breaker
 	return breaker
 }
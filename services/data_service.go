@@ -2,213 +2,122 @@
 package services
 
 import (
-	"math/rand"
-	"time"
+	"context"
+	"math"
 	"trading-platform-backend/models"
+	"trading-platform-backend/repository"
 )
 
-type DataService struct{}
+type DataService struct {
+	orders    repository.OrderRepository
+	positions *PositionService
+}
 
-func NewDataService() *DataService {
-	return &DataService{}
+func NewDataService(orders repository.OrderRepository, positions *PositionService) *DataService {
+	return &DataService{
+		orders:    orders,
+		positions: positions,
+	}
 }
 
-// GetHoldings returns mock holdings data
-func (s *DataService) GetHoldings(userID uint) *models.HoldingsResponse {
-	holdings := []models.Holdings{
-		{
-			Symbol:       "RELIANCE",
-			Quantity:     10,
-			AveragePrice: 2450.50,
-			CurrentPrice: 2485.20,
-			PNL:          347.00,
-			PNLPercent:   1.42,
-		},
-		{
-			Symbol:       "TCS",
-			Quantity:     5,
-			AveragePrice: 3820.75,
-			CurrentPrice: 3795.30,
-			PNL:          -127.25,
-			PNLPercent:   -0.67,
-		},
-		{
-			Symbol:       "HDFCBANK",
-			Quantity:     8,
-			AveragePrice: 1675.40,
-			CurrentPrice: 1702.80,
-			PNL:          219.20,
-			PNLPercent:   1.64,
-		},
-		{
-			Symbol:       "INFY",
-			Quantity:     15,
-			AveragePrice: 1834.60,
-			CurrentPrice: 1856.90,
-			PNL:          334.50,
-			PNLPercent:   1.22,
-		},
-		{
-			Symbol:       "HINDUNILVR",
-			Quantity:     6,
-			AveragePrice: 2720.30,
-			CurrentPrice: 2698.45,
-			PNL:          -131.10,
-			PNLPercent:   -0.80,
-		},
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+// pnlCard summarizes the portfolio's P&L. Percentages are of the cost of
+// the open positions, and zero when the account is flat.
+func pnlCard(portfolio *Portfolio) models.PNLCard {
+	var invested float64
+	for _, position := range portfolio.Positions {
+		invested += position.AveragePrice * float64(position.Quantity)
+	}
+	percent := func(pnl float64) float64 {
+		if invested == 0 {
+			return 0
+		}
+		return round2(pnl / invested * 100)
 	}
 
-	pnlCard := models.PNLCard{
-		TotalPNL:        642.35,
-		TotalPNLPercent: 0.96,
-		DayPNL:          234.50,
-		DayPNLPercent:   0.35,
-		RealizedPNL:     1250.75,
-		UnrealizedPNL:   642.35,
+	total := round2(portfolio.RealizedPNL + portfolio.UnrealizedPNL)
+	return models.PNLCard{
+		TotalPNL:        total,
+		TotalPNLPercent: percent(total),
+		DayPNL:          portfolio.DayPNL,
+		DayPNLPercent:   percent(portfolio.DayPNL),
+		RealizedPNL:     portfolio.RealizedPNL,
+		UnrealizedPNL:   portfolio.UnrealizedPNL,
+	}
+}
+
+// GetHoldings returns the user's long positions marked to market
+func (s *DataService) GetHoldings(ctx context.Context, userID uint) (*models.HoldingsResponse, error) {
+	portfolio, err := s.positions.Portfolio(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	holdings := []models.Holdings{}
+	for _, position := range portfolio.Positions {
+		if position.PositionType != models.PositionLong {
+			continue
+		}
+		holdings = append(holdings, models.Holdings{
+			Symbol:       position.Symbol,
+			Quantity:     position.Quantity,
+			AveragePrice: position.AveragePrice,
+			CurrentPrice: position.CurrentPrice,
+			PNL:          position.UnrealizedPNL,
+			PNLPercent:   position.UnrealizedPNLPercent,
+		})
 	}
 
 	return &models.HoldingsResponse{
 		Holdings: holdings,
-		PNLCard:  pnlCard,
-	}
+		PNLCard:  pnlCard(portfolio),
+	}, nil
 }
 
-// GetOrderbook returns mock orderbook data
-func (s *DataService) GetOrderbook(userID uint) *models.OrderbookResponse {
-	orders := []models.Order{
-		{
-			ID:        "ORD001",
-			Symbol:    "RELIANCE",
-			OrderType: "BUY",
-			Quantity:  10,
-			Price:     2450.50,
-			Status:    "COMPLETED",
-			OrderTime: time.Now().Add(-2 * time.Hour),
-			ExecutedTime: func() *time.Time {
-				t := time.Now().Add(-2 * time.Hour).Add(5 * time.Minute)
-				return &t
-			}(),
-		},
-		{
-			ID:        "ORD002",
-			Symbol:    "TCS",
-			OrderType: "SELL",
-			Quantity:  3,
-			Price:     3825.00,
-			Status:    "COMPLETED",
-			OrderTime: time.Now().Add(-1 * time.Hour),
-			ExecutedTime: func() *time.Time {
-				t := time.Now().Add(-1 * time.Hour).Add(2 * time.Minute)
-				return &t
-			}(),
-		},
-		{
-			ID:        "ORD003",
-			Symbol:    "HDFCBANK",
-			OrderType: "BUY",
-			Quantity:  5,
-			Price:     1680.25,
-			Status:    "PENDING",
-			OrderTime: time.Now().Add(-30 * time.Minute),
-		},
-		{
-			ID:        "ORD004",
-			Symbol:    "INFY",
-			OrderType: "BUY",
-			Quantity:  8,
-			Price:     1840.00,
-			Status:    "CANCELLED",
-			OrderTime: time.Now().Add(-45 * time.Minute),
-		},
-		{
-			ID:        "ORD005",
-			Symbol:    "ITC",
-			OrderType: "SELL",
-			Quantity:  12,
-			Price:     415.75,
-			Status:    "COMPLETED",
-			OrderTime: time.Now().Add(-3 * time.Hour),
-			ExecutedTime: func() *time.Time {
-				t := time.Now().Add(-3 * time.Hour).Add(1 * time.Minute)
-				return &t
-			}(),
-		},
+// GetOrderbook returns the user's orders from the order repository, with the
+// legs of bracket and OCO orders grouped under their parent
+func (s *DataService) GetOrderbook(ctx context.Context, userID uint) (*models.OrderbookResponse, error) {
+	all, err := s.orders.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, err
 	}
 
-	pnlCard := models.PNLCard{
-		TotalPNL:        1893.10,
-		TotalPNLPercent: 2.84,
-		DayPNL:          567.25,
-		DayPNLPercent:   0.85,
-		RealizedPNL:     1250.75,
-		UnrealizedPNL:   642.35,
+	legs := make(map[string][]models.Order)
+	for _, order := range all {
+		if order.ParentID != "" {
+			legs[order.ParentID] = append(legs[order.ParentID], order)
+		}
+	}
+	orders := []models.Order{}
+	for _, order := range all {
+		if order.ParentID == "" {
+			order.Legs = legs[order.ID]
+			orders = append(orders, order)
+		}
 	}
 
 	return &models.OrderbookResponse{
 		Orders:  orders,
-		PNLCard: pnlCard,
-	}
+		PNLCard: pnlCard(s.positions.portfolio(all)),
+	}, nil
 }
 
-// GetPositions returns mock positions data
-func (s *DataService) GetPositions(userID uint) *models.PositionsResponse {
-	positions := []models.Position{
-		{
-			Symbol:               "BHARTIARTL",
-			Quantity:             20,
-			AveragePrice:         965.30,
-			CurrentPrice:         972.85,
-			UnrealizedPNL:        151.00,
-			UnrealizedPNLPercent: 0.78,
-			PositionType:         "LONG",
-		},
-		{
-			Symbol:               "SBIN",
-			Quantity:             25,
-			AveragePrice:         587.40,
-			CurrentPrice:         582.15,
-			UnrealizedPNL:        -131.25,
-			UnrealizedPNLPercent: -0.89,
-			PositionType:         "LONG",
-		},
-		{
-			Symbol:               "KOTAKBANK",
-			Quantity:             12,
-			AveragePrice:         1789.60,
-			CurrentPrice:         1795.20,
-			UnrealizedPNL:        67.20,
-			UnrealizedPNLPercent: 0.31,
-			PositionType:         "LONG",
-		},
-		{
-			Symbol:               "ITC",
-			Quantity:             30,
-			AveragePrice:         418.75,
-			CurrentPrice:         415.30,
-			UnrealizedPNL:        -103.50,
-			UnrealizedPNLPercent: -0.82,
-			PositionType:         "SHORT",
-		},
+// GetPositions returns the user's open positions marked to market
+func (s *DataService) GetPositions(ctx context.Context, userID uint) (*models.PositionsResponse, error) {
+	portfolio, err := s.positions.Portfolio(ctx, userID)
+	if err != nil {
+		return nil, err
 	}
 
-	pnlCard := models.PNLCard{
-		TotalPNL:        -16.55,
-		TotalPNLPercent: -0.02,
-		DayPNL:          -16.55,
-		DayPNLPercent:   -0.02,
-		RealizedPNL:     0.00,
-		UnrealizedPNL:   -16.55,
+	positions := portfolio.Positions
+	if positions == nil {
+		positions = []models.Position{}
 	}
-
 	return &models.PositionsResponse{
 		Positions: positions,
-		PNLCard:   pnlCard,
-	}
-}
-
-// GenerateRandomPrices generates random price fluctuations for demonstration
-func (s *DataService) GenerateRandomPrices() {
-	// This could be used to simulate real-time price updates
-	// For now, it's a placeholder for future implementation
-	rand.Seed(time.Now().UnixNano())
+		PNLCard:   pnlCard(portfolio),
+	}, nil
 }
@@ -0,0 +1,491 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+	"trading-platform-backend/logger"
+	"trading-platform-backend/metrics"
+	"trading-platform-backend/models"
+	"trading-platform-backend/repository"
+)
+
+// expiryCheckInterval is how often DAY and GTD orders are checked for expiry
+const expiryCheckInterval = 30 * time.Second
+
+// ExecutionEngine fills orders against the simulated last traded price. Stop
+// orders wait in TRIGGER_PENDING until the price crosses their trigger, then
+// behave like the limit (SL) or market (SL-M, TSL) order they turn into; a
+// trailing stop's trigger follows the price on every tick until then. Resting
+// fills are complete and happen at the last traded price; IOC and FOK orders
+// execute on submission against the market maker's quoted depth and never rest.
+// Orders under a trading halt are left untouched until it is lifted.
+type ExecutionEngine struct {
+	orders      repository.OrderRepository
+	halts       repository.HaltStore
+	instruments *InstrumentService
+	prices      *PriceSimulator
+	calendar    *MarketCalendar
+
+	// process serializes evaluation so an order is never filled twice
+	process sync.Mutex
+
+	mu     sync.Mutex
+	latest map[string]Tick // newest unprocessed tick per symbol
+	notify chan struct{}
+}
+
+func NewExecutionEngine(orders repository.OrderRepository, halts repository.HaltStore, instruments *InstrumentService, prices *PriceSimulator, calendar *MarketCalendar) *ExecutionEngine {
+	return &ExecutionEngine{
+		orders:      orders,
+		halts:       halts,
+		instruments: instruments,
+		prices:      prices,
+		calendar:    calendar,
+		latest:      make(map[string]Tick),
+		notify:      make(chan struct{}, 1),
+	}
+}
+
+// Submit records a new order, together with any bracket or OCO legs linked to
+// it, and executes it straight away if the current price allows. Stop orders
+// start out waiting for their trigger and bracket exits for their entry.
+func (e *ExecutionEngine) Submit(ctx context.Context, order *models.Order, legs ...*models.Order) error {
+	e.process.Lock()
+	defer e.process.Unlock()
+
+	orders := append([]*models.Order{order}, legs...)
+	for _, o := range orders {
+		o.Status = initialStatus(o)
+	}
+	if err := e.orders.CreateAll(ctx, orders); err != nil {
+		return err
+	}
+
+	now := e.calendar.Now()
+	price, ok := e.prices.LastPrice(order.Symbol)
+	if e.calendar.SessionAt(now) != SessionNormal || !ok {
+		return nil
+	}
+
+	settled := make(map[string]bool)
+	for _, o := range orders {
+		if settled[o.ID] {
+			continue
+		}
+		changed := false
+		if o.TimeInForce == models.TimeInForceIOC || o.TimeInForce == models.TimeInForceFOK {
+			e.fillImmediately(o, price, now)
+			changed = true
+		} else {
+			changed = e.evaluate(o, price, now)
+		}
+		if !changed {
+			continue
+		}
+		saved, err := e.commit(ctx, o)
+		if err != nil {
+			return err
+		}
+		for _, s := range saved {
+			settled[s.ID] = true
+			// Keep the caller's copies of the legs current
+			for _, leg := range orders {
+				if leg.ID == s.ID && leg != s {
+					*leg = *s
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// Cancel cancels an open order along with its linked legs. Cancelling a
+// bracket whose entry already filled cancels its open exit legs.
+func (e *ExecutionEngine) Cancel(ctx context.Context, orderID string) (*models.Order, error) {
+	e.process.Lock()
+	defer e.process.Unlock()
+
+	order, err := e.orders.GetByID(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if order.IsOpen() || order.Status == models.OrderStatusWaiting {
+		order.Status = models.OrderStatusCancelled
+		_, err := e.commit(ctx, order)
+		return order, err
+	}
+
+	if order.Leg == models.LegEntry {
+		legs, err := e.orders.ListByParent(ctx, order.ID)
+		if err != nil {
+			return nil, err
+		}
+		var cancelled []*models.Order
+		for i := range legs {
+			if legs[i].IsOpen() {
+				legs[i].Status = models.OrderStatusCancelled
+				cancelled = append(cancelled, &legs[i])
+			}
+		}
+		if len(cancelled) > 0 {
+			if err := e.orders.UpdateAll(ctx, cancelled); err != nil {
+				return nil, err
+			}
+			for _, leg := range cancelled {
+				recordTransition(leg)
+			}
+			return order, nil
+		}
+	}
+	return nil, ErrOrderNotCancellable
+}
+
+// CancelAll cancels open orders along with their linked legs and returns how
+// many were cancelled. Legs cancelled along with their parent are skipped
+// and not counted separately.
+func (e *ExecutionEngine) CancelAll(ctx context.Context, orders []models.Order) (int, error) {
+	cancelled := 0
+	for _, order := range orders {
+		_, err := e.Cancel(ctx, order.ID)
+		if errors.Is(err, ErrOrderNotCancellable) {
+			// Already cancelled as a sibling or with its parent
+			continue
+		}
+		if err != nil {
+			return cancelled, err
+		}
+		cancelled++
+	}
+	return cancelled, nil
+}
+
+// fillImmediately executes an IOC or FOK order against the market maker's
+// quoted depth and cancels whatever cannot fill: IOC keeps a partial fill,
+// FOK fills completely or not at all
+func (e *ExecutionEngine) fillImmediately(order *models.Order, price float64, at time.Time) {
+	available := 0
+	if instrument, ok := e.instruments.Get(order.Symbol); ok && marketable(order, price) {
+		available = syntheticDepthQuantity(instrument)
+	}
+
+	fill := min(order.Quantity, available)
+	if order.TimeInForce == models.TimeInForceFOK && fill < order.Quantity {
+		fill = 0
+	}
+
+	order.Status = models.OrderStatusCancelled
+	if fill == order.Quantity {
+		order.Status = models.OrderStatusCompleted
+	}
+	if fill > 0 {
+		order.FilledQuantity = fill
+		order.AveragePrice = price
+		order.ExecutedTime = &at
+	}
+}
+
+// ExpireOrders moves open orders past their expiry to EXPIRED and returns how
+// many expired
+func (e *ExecutionEngine) ExpireOrders(ctx context.Context, now time.Time) (int, error) {
+	e.process.Lock()
+	defer e.process.Unlock()
+
+	orders, err := e.orders.ListExpired(ctx, now)
+	if err != nil {
+		return 0, err
+	}
+	settled := make(map[string]bool)
+	expired := 0
+	for i := range orders {
+		if settled[orders[i].ID] {
+			continue
+		}
+		orders[i].Status = models.OrderStatusExpired
+		saved, err := e.commit(ctx, &orders[i])
+		if err != nil {
+			return expired, err
+		}
+		for _, o := range saved {
+			settled[o.ID] = true
+		}
+		expired++
+		logger.FromContext(ctx).Info("Order expired", "order_id", orders[i].ID, "user_id", orders[i].UserID, "symbol", orders[i].Symbol, "time_in_force", orders[i].TimeInForce)
+	}
+	return expired, nil
+}
+
+// OnTick queues a tick for Start to process. It never blocks, so it is safe to
+// register with PriceSimulator.Subscribe; only the newest tick per symbol is kept.
+func (e *ExecutionEngine) OnTick(tick Tick) {
+	e.mu.Lock()
+	e.latest[tick.Symbol] = tick
+	e.mu.Unlock()
+
+	select {
+	case e.notify <- struct{}{}:
+	default:
+	}
+}
+
+// Start processes queued ticks and expires orders until ctx is cancelled
+func (e *ExecutionEngine) Start(ctx context.Context) {
+	expiry := time.NewTicker(expiryCheckInterval)
+	defer expiry.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-expiry.C:
+			if _, err := e.ExpireOrders(ctx, e.calendar.Now()); err != nil {
+				logger.FromContext(ctx).Error("Failed to expire orders", "error", err)
+			}
+			continue
+		case <-e.notify:
+		}
+
+		e.mu.Lock()
+		ticks := e.latest
+		e.latest = make(map[string]Tick)
+		e.mu.Unlock()
+
+		for _, tick := range ticks {
+			if err := e.ProcessTick(ctx, tick); err != nil {
+				logger.FromContext(ctx).Error("Failed to process tick", "symbol", tick.Symbol, "error", err)
+			}
+		}
+	}
+}
+
+// ProcessTick triggers and fills the symbol's open orders at the tick price
+func (e *ExecutionEngine) ProcessTick(ctx context.Context, tick Tick) error {
+	e.process.Lock()
+	defer e.process.Unlock()
+
+	marketHalted, haltedUsers, err := e.haltedScopes(ctx, tick.Symbol)
+	if err != nil || marketHalted {
+		return err
+	}
+	orders, err := e.orders.ListOpenBySymbol(ctx, tick.Symbol)
+	if err != nil {
+		return err
+	}
+	// A fill can cancel a sibling further down the list; skip those
+	settled := make(map[string]bool)
+	for i := range orders {
+		if settled[orders[i].ID] || haltedUsers[orders[i].UserID] {
+			continue
+		}
+		if !e.evaluate(&orders[i], tick.Price, tick.Time) {
+			continue
+		}
+		saved, err := e.commit(ctx, &orders[i])
+		if err != nil {
+			return err
+		}
+		for _, o := range saved {
+			settled[o.ID] = true
+		}
+	}
+	return nil
+}
+
+// haltedScopes reports whether the platform or symbol is halted, and which
+// users are
+func (e *ExecutionEngine) haltedScopes(ctx context.Context, symbol string) (bool, map[uint]bool, error) {
+	halts, err := e.halts.List(ctx)
+	if err != nil {
+		return false, nil, err
+	}
+	users := make(map[uint]bool)
+	for _, halt := range halts {
+		switch halt.Scope {
+		case models.HaltScopePlatform:
+			return true, nil, nil
+		case models.HaltScopeSymbol:
+			if halt.Target == symbol {
+				return true, nil, nil
+			}
+		case models.HaltScopeUser:
+			if id, err := strconv.ParseUint(halt.Target, 10, 0); err == nil {
+				users[uint(id)] = true
+			}
+		}
+	}
+	return false, users, nil
+}
+
+// evaluate applies one price to an open order and reports whether it changed
+func (e *ExecutionEngine) evaluate(order *models.Order, price float64, at time.Time) bool {
+	changed := false
+
+	if order.Status == models.OrderStatusTriggerPending {
+		if order.OrderType == models.OrderTypeTrailing {
+			instrument, _ := e.instruments.Get(order.Symbol)
+			changed = trail(order, price, instrument.TickSize)
+		}
+		if !stopTriggered(order, price) {
+			return changed
+		}
+		order.Status = models.OrderStatusPending
+		changed = true
+	}
+
+	if order.Status == models.OrderStatusPending && marketable(order, price) {
+		order.Status = models.OrderStatusCompleted
+		order.FilledQuantity = order.Quantity
+		order.AveragePrice = price
+		order.ExecutedTime = &at
+		changed = true
+	}
+	return changed
+}
+
+// commit saves a changed order together with the linked legs its new status
+// affects in one transaction, and returns everything it saved
+func (e *ExecutionEngine) commit(ctx context.Context, order *models.Order) ([]*models.Order, error) {
+	linked, err := e.linkedChanges(ctx, order)
+	if err != nil {
+		return nil, err
+	}
+	saved := append([]*models.Order{order}, linked...)
+	if err := e.orders.UpdateAll(ctx, saved); err != nil {
+		return nil, err
+	}
+	for _, o := range saved {
+		recordTransition(o)
+	}
+	return saved, nil
+}
+
+// linkedChanges applies an order's new status to its linked legs: a filled
+// bracket entry releases its exit legs and an entry that will never fill
+// takes them with it, while a leg that fills or is cancelled cancels its
+// siblings
+func (e *ExecutionEngine) linkedChanges(ctx context.Context, order *models.Order) ([]*models.Order, error) {
+	if order.OrderClass != models.OrderClassBracket && order.OrderClass != models.OrderClassOCO {
+		return nil, nil
+	}
+
+	if order.Leg == models.LegEntry {
+		if order.IsOpen() {
+			return nil, nil
+		}
+		legs, err := e.orders.ListByParent(ctx, order.ID)
+		if err != nil {
+			return nil, err
+		}
+		var changed []*models.Order
+		for i := range legs {
+			if legs[i].Status != models.OrderStatusWaiting {
+				continue
+			}
+			switch order.Status {
+			case models.OrderStatusCompleted:
+				legs[i].Status = activeStatus(&legs[i])
+				legs[i].Quantity = order.FilledQuantity
+			case models.OrderStatusExpired:
+				legs[i].Status = models.OrderStatusExpired
+			default:
+				legs[i].Status = models.OrderStatusCancelled
+			}
+			changed = append(changed, &legs[i])
+		}
+		return changed, nil
+	}
+
+	if order.IsOpen() {
+		return nil, nil
+	}
+	siblings, err := e.siblings(ctx, order)
+	if err != nil {
+		return nil, err
+	}
+	var changed []*models.Order
+	for i := range siblings {
+		if !siblings[i].IsOpen() && siblings[i].Status != models.OrderStatusWaiting {
+			continue
+		}
+		siblings[i].Status = models.OrderStatusCancelled
+		if order.Status == models.OrderStatusExpired {
+			siblings[i].Status = models.OrderStatusExpired
+		}
+		changed = append(changed, &siblings[i])
+	}
+	return changed, nil
+}
+
+// siblings returns the other exit legs of a bracket, or the other half of an
+// OCO pair, whose first leg is the parent of the second
+func (e *ExecutionEngine) siblings(ctx context.Context, order *models.Order) ([]models.Order, error) {
+	parentID := order.ParentID
+	if parentID == "" {
+		parentID = order.ID
+	}
+	legs, err := e.orders.ListByParent(ctx, parentID)
+	if err != nil {
+		return nil, err
+	}
+	if order.OrderClass == models.OrderClassOCO && order.ParentID != "" {
+		parent, err := e.orders.GetByID(ctx, order.ParentID)
+		if err != nil {
+			return nil, err
+		}
+		legs = append(legs, *parent)
+	}
+
+	siblings := legs[:0]
+	for _, leg := range legs {
+		if leg.ID != order.ID {
+			siblings = append(siblings, leg)
+		}
+	}
+	return siblings, nil
+}
+
+// initialStatus is the status a new order starts in
+func initialStatus(order *models.Order) string {
+	if order.OrderClass == models.OrderClassBracket && order.Leg != models.LegEntry {
+		return models.OrderStatusWaiting
+	}
+	return activeStatus(order)
+}
+
+// activeStatus is the status of an order that is ready to execute
+func activeStatus(order *models.Order) string {
+	if models.IsStopOrderType(order.OrderType) {
+		return models.OrderStatusTriggerPending
+	}
+	return models.OrderStatusPending
+}
+
+// recordTransition counts orders that reached a final status
+func recordTransition(order *models.Order) {
+	if !order.IsOpen() {
+		metrics.OrderTransitionsTotal.WithLabelValues(order.Status, order.TimeInForce).Inc()
+	}
+}
+
+// stopTriggered reports whether price has reached the order's trigger: a buy
+// stop fires when the price rises to it, a sell stop when the price falls to it
+func stopTriggered(order *models.Order, price float64) bool {
+	if order.Side == models.SideBuy {
+		return price >= order.TriggerPrice
+	}
+	return price <= order.TriggerPrice
+}
+
+// marketable reports whether an active order can fill at price
+func marketable(order *models.Order, price float64) bool {
+	switch order.OrderType {
+	case models.OrderTypeMarket, models.OrderTypeStopLoss, models.OrderTypeTrailing:
+		return true
+	}
+	if order.Side == models.SideBuy {
+		return price <= order.Price
+	}
+	return price >= order.Price
+}
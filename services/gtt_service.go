@@ -0,0 +1,351 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+	"trading-platform-backend/logger"
+	"trading-platform-backend/models"
+	"trading-platform-backend/repository"
+)
+
+// gttValidity is how long a GTT stays active without firing
+const gttValidity = 365 * 24 * time.Hour
+
+// RejectInvalidGTT rejects a GTT whose legs do not fit its type
+const RejectInvalidGTT = "INVALID_GTT"
+
+var (
+	// ErrGTTNotFound is returned when a GTT does not exist or belongs to another user
+	ErrGTTNotFound = errors.New("GTT not found")
+	// ErrGTTNotActive is returned when modifying or cancelling a GTT that already ended
+	ErrGTTNotActive = errors.New("GTT is no longer active")
+)
+
+// GTTService keeps good-till-triggered orders: standing triggers, stored in
+// the database so they survive restarts, that place a regular order through
+// the order service when the last price reaches them
+type GTTService struct {
+	gtts        repository.GTTRepository
+	orders      *OrderService
+	instruments *InstrumentService
+	prices      *PriceSimulator
+	calendar    *MarketCalendar
+
+	// process serializes evaluation so a GTT never fires twice
+	process sync.Mutex
+
+	mu     sync.Mutex
+	latest map[string]Tick // newest unprocessed tick per symbol
+	notify chan struct{}
+}
+
+func NewGTTService(gtts repository.GTTRepository, orders *OrderService, instruments *InstrumentService, prices *PriceSimulator, calendar *MarketCalendar) *GTTService {
+	return &GTTService{
+		gtts:        gtts,
+		orders:      orders,
+		instruments: instruments,
+		prices:      prices,
+		calendar:    calendar,
+		latest:      make(map[string]Tick),
+		notify:      make(chan struct{}, 1),
+	}
+}
+
+// Create validates and stores a new GTT. Unlike orders, GTTs can be set while
+// the market is closed.
+func (s *GTTService) Create(ctx context.Context, userID uint, req models.GTTRequest) (*models.GTT, error) {
+	instrument, legs, err := s.validate(req)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.calendar.Now()
+	gtt := &models.GTT{
+		ID:        newID("GTT"),
+		UserID:    userID,
+		Type:      req.Type,
+		Symbol:    instrument.Symbol,
+		Side:      req.Side,
+		Quantity:  req.Quantity,
+		Legs:      legs,
+		Status:    models.GTTStatusActive,
+		CreatedAt: now,
+		UpdatedAt: now,
+		ExpiresAt: now.Add(gttValidity),
+	}
+	last, _ := s.prices.LastPrice(gtt.Symbol)
+	event := &models.GTTEvent{Event: models.GTTEventCreated, LastPrice: last, CreatedAt: now}
+	if err := s.gtts.Create(ctx, gtt, event); err != nil {
+		return nil, err
+	}
+	return gtt, nil
+}
+
+// Modify replaces an active GTT's trigger and order details, keeping its expiry
+func (s *GTTService) Modify(ctx context.Context, userID uint, id string, req models.GTTRequest) (*models.GTT, error) {
+	instrument, legs, err := s.validate(req)
+	if err != nil {
+		return nil, err
+	}
+
+	s.process.Lock()
+	defer s.process.Unlock()
+
+	gtt, err := s.active(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	now := s.calendar.Now()
+	gtt.Type, gtt.Symbol, gtt.Side, gtt.Quantity, gtt.Legs = req.Type, instrument.Symbol, req.Side, req.Quantity, legs
+	gtt.UpdatedAt = now
+
+	last, _ := s.prices.LastPrice(gtt.Symbol)
+	event := &models.GTTEvent{Event: models.GTTEventModified, LastPrice: last, CreatedAt: now}
+	if err := s.gtts.Update(ctx, gtt, event); err != nil {
+		return nil, err
+	}
+	return gtt, nil
+}
+
+// Cancel deactivates one of the user's active GTTs
+func (s *GTTService) Cancel(ctx context.Context, userID uint, id string) (*models.GTT, error) {
+	s.process.Lock()
+	defer s.process.Unlock()
+
+	gtt, err := s.active(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	now := s.calendar.Now()
+	gtt.Status = models.GTTStatusCancelled
+	gtt.UpdatedAt = now
+
+	event := &models.GTTEvent{Event: models.GTTEventCancelled, CreatedAt: now}
+	if err := s.gtts.Update(ctx, gtt, event); err != nil {
+		return nil, err
+	}
+	return gtt, nil
+}
+
+// Get returns one of the user's GTTs with its event history
+func (s *GTTService) Get(ctx context.Context, userID uint, id string) (*models.GTT, error) {
+	gtt, err := s.get(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if gtt.Events, err = s.gtts.ListEvents(ctx, gtt.ID); err != nil {
+		return nil, err
+	}
+	return gtt, nil
+}
+
+// List returns the user's GTTs, newest first
+func (s *GTTService) List(ctx context.Context, userID uint) ([]models.GTT, error) {
+	gtts, err := s.gtts.ListByUser(ctx, userID)
+	if gtts == nil {
+		gtts = []models.GTT{}
+	}
+	return gtts, err
+}
+
+func (s *GTTService) get(ctx context.Context, userID uint, id string) (*models.GTT, error) {
+	gtt, err := s.gtts.GetByID(ctx, id)
+	if errors.Is(err, repository.ErrNotFound) || (err == nil && gtt.UserID != userID) {
+		return nil, ErrGTTNotFound
+	}
+	return gtt, err
+}
+
+func (s *GTTService) active(ctx context.Context, userID uint, id string) (*models.GTT, error) {
+	gtt, err := s.get(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if gtt.Status != models.GTTStatusActive {
+		return nil, ErrGTTNotActive
+	}
+	return gtt, nil
+}
+
+// validate checks the request against the instrument master and fixes each
+// leg's condition from the side of the last price its trigger sits on
+func (s *GTTService) validate(req models.GTTRequest) (models.Instrument, []models.GTTLeg, error) {
+	instrument, ok := s.instruments.Get(req.Symbol)
+	if !ok {
+		return instrument, nil, rejectOrder(RejectUnknownSymbol, "unknown symbol %q", req.Symbol)
+	}
+	if instrument.TradingStatus != models.InstrumentActive && instrument.TradingStatus != models.InstrumentHalted {
+		return instrument, nil, rejectOrder(RejectNotTradable, "%s is not tradable (status %s)", instrument.Symbol, instrument.TradingStatus)
+	}
+	if req.Quantity%instrument.LotSize != 0 {
+		return instrument, nil, rejectOrder(RejectInvalidLotSize, "quantity must be a multiple of the lot size %d", instrument.LotSize)
+	}
+	want := 1
+	if req.Type == models.GTTTypeOCO {
+		want = 2
+	}
+	if len(req.Legs) != want {
+		return instrument, nil, rejectOrder(RejectInvalidGTT, "%s GTTs take %d leg(s)", req.Type, want)
+	}
+
+	last, _ := s.prices.LastPrice(instrument.Symbol)
+	legs := make([]models.GTTLeg, len(req.Legs))
+	for i, leg := range req.Legs {
+		switch {
+		case leg.OrderType == models.OrderTypeLimit && leg.Price == 0:
+			return instrument, nil, rejectOrder(RejectPriceRequired, "LIMIT orders require a price")
+		case leg.OrderType == models.OrderTypeMarket && leg.Price != 0:
+			return instrument, nil, rejectOrder(RejectPriceNotAllowed, "MARKET orders execute at the market and must not set a price")
+		case !OnTick(leg.TriggerPrice, instrument.TickSize):
+			return instrument, nil, rejectOrder(RejectInvalidTick, "trigger price %g is not a multiple of the tick size %g", leg.TriggerPrice, instrument.TickSize)
+		case !OnTick(leg.Price, instrument.TickSize):
+			return instrument, nil, rejectOrder(RejectInvalidTick, "price %g is not a multiple of the tick size %g", leg.Price, instrument.TickSize)
+		case leg.TriggerPrice == last:
+			return instrument, nil, rejectOrder(RejectInvalidTrigger, "trigger price must differ from the last price %g", last)
+		}
+
+		condition := models.GTTConditionBelow
+		if leg.TriggerPrice > last {
+			condition = models.GTTConditionAbove
+		}
+		legs[i] = models.GTTLeg{TriggerPrice: leg.TriggerPrice, Condition: condition, OrderType: leg.OrderType, Price: leg.Price}
+	}
+	if req.Type == models.GTTTypeOCO && legs[0].Condition == legs[1].Condition {
+		return instrument, nil, rejectOrder(RejectInvalidGTT, "OCO triggers must sit either side of the last price %g", last)
+	}
+	return instrument, legs, nil
+}
+
+// OnTick queues a tick for Start to process. It never blocks, so it is safe to
+// register with PriceSimulator.Subscribe; only the newest tick per symbol is kept.
+func (s *GTTService) OnTick(tick Tick) {
+	s.mu.Lock()
+	s.latest[tick.Symbol] = tick
+	s.mu.Unlock()
+
+	select {
+	case s.notify <- struct{}{}:
+	default:
+	}
+}
+
+// Start evaluates queued ticks and expires GTTs until ctx is cancelled
+func (s *GTTService) Start(ctx context.Context) {
+	expiry := time.NewTicker(expiryCheckInterval)
+	defer expiry.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-expiry.C:
+			if _, err := s.ExpireGTTs(ctx, s.calendar.Now()); err != nil {
+				logger.FromContext(ctx).Error("Failed to expire GTTs", "error", err)
+			}
+			continue
+		case <-s.notify:
+		}
+
+		s.mu.Lock()
+		ticks := s.latest
+		s.latest = make(map[string]Tick)
+		s.mu.Unlock()
+
+		for _, tick := range ticks {
+			if err := s.ProcessTick(ctx, tick); err != nil {
+				logger.FromContext(ctx).Error("Failed to evaluate GTTs", "symbol", tick.Symbol, "error", err)
+			}
+		}
+	}
+}
+
+// ProcessTick fires the symbol's active GTTs whose trigger the tick reached.
+// A GTT fires once: it is TRIGGERED if its order was placed and REJECTED if
+// the order service turned the order down.
+func (s *GTTService) ProcessTick(ctx context.Context, tick Tick) error {
+	s.process.Lock()
+	defer s.process.Unlock()
+
+	gtts, err := s.gtts.ListActiveBySymbol(ctx, tick.Symbol)
+	if err != nil {
+		return err
+	}
+	for i := range gtts {
+		leg, ok := firedLeg(&gtts[i], tick.Price)
+		if !ok {
+			continue
+		}
+		if err := s.fire(ctx, &gtts[i], leg, tick); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *GTTService) fire(ctx context.Context, gtt *models.GTT, leg models.GTTLeg, tick Tick) error {
+	order, err := s.orders.PlaceOrder(ctx, gtt.UserID, models.PlaceOrderRequest{
+		Symbol:      gtt.Symbol,
+		Side:        gtt.Side,
+		OrderType:   leg.OrderType,
+		Quantity:    gtt.Quantity,
+		Price:       leg.Price,
+		TimeInForce: models.TimeInForceDay,
+	})
+	var orderErr *OrderError
+	if err != nil && !errors.As(err, &orderErr) {
+		return err
+	}
+
+	now := s.calendar.Now()
+	gtt.UpdatedAt = now
+	gtt.TriggeredAt = &now
+	event := &models.GTTEvent{LastPrice: tick.Price, CreatedAt: now}
+	if orderErr != nil {
+		gtt.Status = models.GTTStatusRejected
+		event.Event = models.GTTEventOrderRejected
+		event.Message = orderErr.Code + ": " + orderErr.Message
+	} else {
+		gtt.Status = models.GTTStatusTriggered
+		gtt.OrderID = order.ID
+		event.Event = models.GTTEventTriggered
+		event.OrderID = order.ID
+	}
+	if err := s.gtts.Update(ctx, gtt, event); err != nil {
+		return err
+	}
+	logger.FromContext(ctx).Info("GTT triggered", "gtt_id", gtt.ID, "user_id", gtt.UserID, "symbol", gtt.Symbol, "trigger_price", leg.TriggerPrice, "last_price", tick.Price, "status", gtt.Status)
+	return nil
+}
+
+// ExpireGTTs moves active GTTs past their expiry to EXPIRED and returns how
+// many expired
+func (s *GTTService) ExpireGTTs(ctx context.Context, now time.Time) (int, error) {
+	s.process.Lock()
+	defer s.process.Unlock()
+
+	gtts, err := s.gtts.ListExpired(ctx, now)
+	if err != nil {
+		return 0, err
+	}
+	for i := range gtts {
+		gtts[i].Status = models.GTTStatusExpired
+		gtts[i].UpdatedAt = now
+		if err := s.gtts.Update(ctx, &gtts[i], &models.GTTEvent{Event: models.GTTEventExpired, CreatedAt: now}); err != nil {
+			return i, err
+		}
+	}
+	return len(gtts), nil
+}
+
+// firedLeg returns the leg whose trigger price has reached
+func firedLeg(gtt *models.GTT, price float64) (models.GTTLeg, bool) {
+	for _, leg := range gtt.Legs {
+		if (leg.Condition == models.GTTConditionAbove && price >= leg.TriggerPrice) ||
+			(leg.Condition == models.GTTConditionBelow && price <= leg.TriggerPrice) {
+			return leg, true
+		}
+	}
+	return models.GTTLeg{}, false
+}
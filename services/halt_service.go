@@ -0,0 +1,163 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"trading-platform-backend/logger"
+	"trading-platform-backend/models"
+	"trading-platform-backend/repository"
+)
+
+var (
+	// ErrHaltNotFound is returned when lifting a halt that is not in place
+	ErrHaltNotFound = errors.New("trading halt not found")
+	// ErrHaltTargetNotFound is returned when halting an unknown user or symbol
+	ErrHaltTargetNotFound = errors.New("halt target not found")
+)
+
+// HaltService manages the kill switches that stop order placement for a
+// user, a symbol or the whole platform. Halts live in a shared store so
+// every instance enforces them on the next order.
+type HaltService struct {
+	halts       repository.HaltStore
+	users       repository.UserRepository
+	orders      repository.OrderRepository
+	engine      *ExecutionEngine
+	instruments *InstrumentService
+	calendar    *MarketCalendar
+}
+
+func NewHaltService(halts repository.HaltStore, users repository.UserRepository, orders repository.OrderRepository, engine *ExecutionEngine, instruments *InstrumentService, calendar *MarketCalendar) *HaltService {
+	return &HaltService{
+		halts:       halts,
+		users:       users,
+		orders:      orders,
+		engine:      engine,
+		instruments: instruments,
+		calendar:    calendar,
+	}
+}
+
+// Halt stops order placement in the halt's scope and, if cancelOpen is set,
+// cancels the open orders there along with their linked legs
+func (s *HaltService) Halt(ctx context.Context, halt models.TradingHalt, cancelOpen bool) (*models.TradingHalt, error) {
+	switch halt.Scope {
+	case models.HaltScopeUser:
+		id, err := strconv.ParseUint(halt.Target, 10, 0)
+		if err != nil {
+			return nil, ErrHaltTargetNotFound
+		}
+		if _, err := s.users.GetByID(ctx, uint(id)); errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrHaltTargetNotFound
+		} else if err != nil {
+			return nil, err
+		}
+	case models.HaltScopeSymbol:
+		instrument, ok := s.instruments.Get(halt.Target)
+		if !ok {
+			return nil, ErrHaltTargetNotFound
+		}
+		halt.Target = instrument.Symbol
+	}
+
+	halt.HaltedAt = s.calendar.Now()
+	if err := s.halts.Set(ctx, halt); err != nil {
+		return nil, err
+	}
+
+	if cancelOpen {
+		cancelled, err := s.cancelOpenOrders(ctx, halt)
+		halt.CancelledOrders = cancelled
+		if err != nil {
+			return &halt, err
+		}
+	}
+
+	logger.FromContext(ctx).Warn("Trading halted", "scope", halt.Scope, "target", halt.Target, "reason", halt.Reason,
+		"halted_by", halt.HaltedBy, "cancelled_orders", halt.CancelledOrders)
+	return &halt, nil
+}
+
+// Resume lifts a halt
+func (s *HaltService) Resume(ctx context.Context, scope, target string) error {
+	if scope == models.HaltScopeSymbol {
+		target = strings.ToUpper(target)
+	}
+	removed, err := s.halts.Delete(ctx, repository.HaltKey{Scope: scope, Target: target})
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return ErrHaltNotFound
+	}
+	logger.FromContext(ctx).Warn("Trading resumed", "scope", scope, "target", target)
+	return nil
+}
+
+// List returns every halt in place, oldest first
+func (s *HaltService) List(ctx context.Context) ([]models.TradingHalt, error) {
+	return s.halts.List(ctx)
+}
+
+// Check rejects an order when the platform, the user or the symbol is halted
+func (s *HaltService) Check(ctx context.Context, userID uint, symbol string) error {
+	halts, err := s.halts.Find(ctx,
+		repository.HaltKey{Scope: models.HaltScopePlatform},
+		repository.HaltKey{Scope: models.HaltScopeUser, Target: strconv.FormatUint(uint64(userID), 10)},
+		repository.HaltKey{Scope: models.HaltScopeSymbol, Target: symbol},
+	)
+	if err != nil {
+		return err
+	}
+	if len(halts) == 0 {
+		return nil
+	}
+
+	halt := halts[0]
+	switch halt.Scope {
+	case models.HaltScopePlatform:
+		return rejectOrder(RejectTradingHalted, "trading is halted on the platform: %s", halt.Reason)
+	case models.HaltScopeUser:
+		return rejectOrder(RejectTradingHalted, "trading is halted for your account: %s", halt.Reason)
+	default:
+		return rejectOrder(RejectTradingHalted, "trading is halted for %s: %s", halt.Target, halt.Reason)
+	}
+}
+
+// cancelOpenOrders cancels the open orders in the halt's scope and returns
+// how many were cancelled. Legs cancelled along with their parent are not
+// counted separately.
+func (s *HaltService) cancelOpenOrders(ctx context.Context, halt models.TradingHalt) (int, error) {
+	var open []models.Order
+	switch halt.Scope {
+	case models.HaltScopeUser:
+		id, _ := strconv.ParseUint(halt.Target, 10, 0)
+		orders, err := s.orders.ListByUser(ctx, uint(id))
+		if err != nil {
+			return 0, err
+		}
+		for _, order := range orders {
+			if order.IsOpen() {
+				open = append(open, order)
+			}
+		}
+	case models.HaltScopeSymbol:
+		orders, err := s.orders.ListOpenBySymbol(ctx, halt.Target)
+		if err != nil {
+			return 0, err
+		}
+		open = orders
+	case models.HaltScopePlatform:
+		for _, instrument := range s.instruments.List() {
+			orders, err := s.orders.ListOpenBySymbol(ctx, instrument.Symbol)
+			if err != nil {
+				return 0, err
+			}
+			open = append(open, orders...)
+		}
+	}
+
+	return s.engine.CancelAll(ctx, open)
+}
@@ -0,0 +1,232 @@
+package services
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"trading-platform-backend/models"
+	"trading-platform-backend/repository"
+)
+
+// InstrumentService serves the instrument master from an in-memory cache that
+// is loaded from the repository and refreshed after every import
+type InstrumentService struct {
+	repo repository.InstrumentRepository
+
+	mu       sync.RWMutex
+	bySymbol map[string]models.Instrument
+	sorted   []models.Instrument
+}
+
+func NewInstrumentService(repo repository.InstrumentRepository) *InstrumentService {
+	return &InstrumentService{
+		repo:     repo,
+		bySymbol: make(map[string]models.Instrument),
+	}
+}
+
+// Refresh reloads the cache from the repository
+func (s *InstrumentService) Refresh(ctx context.Context) error {
+	instruments, err := s.repo.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	bySymbol := make(map[string]models.Instrument, len(instruments))
+	for _, instrument := range instruments {
+		bySymbol[instrument.Symbol] = instrument
+	}
+	sort.Slice(instruments, func(i, j int) bool { return instruments[i].Symbol < instruments[j].Symbol })
+
+	s.mu.Lock()
+	s.bySymbol = bySymbol
+	s.sorted = instruments
+	s.mu.Unlock()
+	return nil
+}
+
+// Get looks up an instrument by symbol (case-insensitive)
+func (s *InstrumentService) Get(symbol string) (models.Instrument, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	instrument, ok := s.bySymbol[strings.ToUpper(symbol)]
+	return instrument, ok
+}
+
+// List returns all instruments ordered by symbol
+func (s *InstrumentService) List() []models.Instrument {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return append([]models.Instrument(nil), s.sorted...)
+}
+
+// Search matches query against symbols and names. Exact symbol matches rank
+// first, then symbol prefixes, then names containing the query.
+func (s *InstrumentService) Search(query string, limit int) []models.Instrument {
+	query = strings.ToUpper(strings.TrimSpace(query))
+	if query == "" {
+		all := s.List()
+		if limit > 0 && len(all) > limit {
+			all = all[:limit]
+		}
+		return all
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var exact, prefix, name []models.Instrument
+	for _, instrument := range s.sorted {
+		switch {
+		case instrument.Symbol == query:
+			exact = append(exact, instrument)
+		case strings.HasPrefix(instrument.Symbol, query):
+			prefix = append(prefix, instrument)
+		case strings.Contains(strings.ToUpper(instrument.Name), query):
+			name = append(name, instrument)
+		}
+	}
+
+	results := append(append(exact, prefix...), name...)
+	if limit > 0 && len(results) > limit {
+		results = results[:limit]
+	}
+	return results
+}
+
+// LoadCSV imports instruments from CSV into the repository and refreshes the
+// cache. It returns the number of instruments imported.
+func (s *InstrumentService) LoadCSV(ctx context.Context, r io.Reader) (int, error) {
+	instruments, err := ParseInstrumentsCSV(r)
+	if err != nil {
+		return 0, err
+	}
+	if err := s.repo.Upsert(ctx, instruments); err != nil {
+		return 0, err
+	}
+	return len(instruments), s.Refresh(ctx)
+}
+
+// ParseInstrumentsCSV reads an instrument master with a header row containing
+// symbol, exchange, isin, name, instrument_type, tick_size, lot_size,
+// trading_status, prev_close, price_band, var_margin and elm_margin. Column
+// order is free; isin, name, trading_status, prev_close, price_band and the
+// margin rates are optional. A missing price_band defaults to
+// DefaultPriceBand; 0 disables the band. Without margin rates the configured
+// default rate applies.
+func ParseInstrumentsCSV(r io.Reader) ([]models.Instrument, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("read header: %w", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, required := range []string{"symbol", "exchange", "instrument_type", "tick_size", "lot_size"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("missing required column %q", required)
+		}
+	}
+
+	var instruments []models.Instrument
+	var problems []string
+	seen := map[string]bool{}
+
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		field := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		instrument := models.Instrument{
+			Symbol:         strings.ToUpper(field("symbol")),
+			Exchange:       strings.ToUpper(field("exchange")),
+			ISIN:           strings.ToUpper(field("isin")),
+			Name:           field("name"),
+			InstrumentType: strings.ToUpper(field("instrument_type")),
+			TradingStatus:  strings.ToUpper(field("trading_status")),
+		}
+		if instrument.TradingStatus == "" {
+			instrument.TradingStatus = models.InstrumentActive
+		}
+
+		tickSize, tickErr := strconv.ParseFloat(field("tick_size"), 64)
+		lotSize, lotErr := strconv.Atoi(field("lot_size"))
+		prevClose := 0.0
+		var closeErr error
+		if raw := field("prev_close"); raw != "" {
+			prevClose, closeErr = strconv.ParseFloat(raw, 64)
+		}
+
+		priceBand := float64(DefaultPriceBand)
+		var bandErr error
+		if raw := field("price_band"); raw != "" {
+			priceBand, bandErr = strconv.ParseFloat(raw, 64)
+		}
+
+		var varMargin, elmMargin float64
+		var varErr, elmErr error
+		if raw := field("var_margin"); raw != "" {
+			varMargin, varErr = strconv.ParseFloat(raw, 64)
+		}
+		if raw := field("elm_margin"); raw != "" {
+			elmMargin, elmErr = strconv.ParseFloat(raw, 64)
+		}
+
+		switch {
+		case instrument.Symbol == "":
+			problems = append(problems, fmt.Sprintf("line %d: symbol is required", line))
+		case seen[instrument.Symbol]:
+			problems = append(problems, fmt.Sprintf("line %d: duplicate symbol %s", line, instrument.Symbol))
+		case tickErr != nil || tickSize <= 0:
+			problems = append(problems, fmt.Sprintf("line %d: invalid tick_size %q", line, field("tick_size")))
+		case lotErr != nil || lotSize < 1:
+			problems = append(problems, fmt.Sprintf("line %d: invalid lot_size %q", line, field("lot_size")))
+		case closeErr != nil || prevClose < 0:
+			problems = append(problems, fmt.Sprintf("line %d: invalid prev_close %q", line, field("prev_close")))
+		case bandErr != nil || priceBand < 0 || priceBand >= 100:
+			problems = append(problems, fmt.Sprintf("line %d: invalid price_band %q", line, field("price_band")))
+		case varErr != nil || elmErr != nil || varMargin < 0 || elmMargin < 0 || varMargin+elmMargin > 100:
+			problems = append(problems, fmt.Sprintf("line %d: invalid var_margin %q or elm_margin %q", line, field("var_margin"), field("elm_margin")))
+		case instrument.TradingStatus != models.InstrumentActive && instrument.TradingStatus != models.InstrumentSuspended && instrument.TradingStatus != models.InstrumentHalted:
+			problems = append(problems, fmt.Sprintf("line %d: unknown trading_status %q", line, instrument.TradingStatus))
+		default:
+			instrument.TickSize = tickSize
+			instrument.LotSize = lotSize
+			instrument.PrevClose = prevClose
+			instrument.PriceBand = priceBand
+			instrument.VaRMargin = varMargin
+			instrument.ELMMargin = elmMargin
+			seen[instrument.Symbol] = true
+			instruments = append(instruments, instrument)
+		}
+	}
+
+	if len(problems) > 0 {
+		return nil, fmt.Errorf("invalid instrument file:\n  %s", strings.Join(problems, "\n  "))
+	}
+	return instruments, nil
+}
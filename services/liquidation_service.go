@@ -0,0 +1,221 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+	"trading-platform-backend/config"
+	"trading-platform-backend/logger"
+	"trading-platform-backend/metrics"
+	"trading-platform-backend/models"
+	"trading-platform-backend/repository"
+)
+
+// LiquidationService is the risk job that closes out accounts. An account
+// whose equity falls below the configured share of its used margin has its
+// open orders cancelled and every position closed at market; INTRADAY
+// positions are squared off shortly before the normal session ends.
+type LiquidationService struct {
+	orders    repository.OrderRepository
+	positions *PositionService
+	margins   *MarginService
+	placer    *OrderService
+	engine    *ExecutionEngine
+	calendar  *MarketCalendar
+	config    config.LiquidationConfig
+
+	// process serializes runs so an account is never closed out twice at once
+	process sync.Mutex
+}
+
+func NewLiquidationService(orders repository.OrderRepository, positions *PositionService, margins *MarginService, placer *OrderService, engine *ExecutionEngine, calendar *MarketCalendar, cfg config.LiquidationConfig) *LiquidationService {
+	return &LiquidationService{
+		orders:    orders,
+		positions: positions,
+		margins:   margins,
+		placer:    placer,
+		engine:    engine,
+		calendar:  calendar,
+		config:    cfg,
+	}
+}
+
+// Start evaluates every account on the configured interval until ctx is done
+func (s *LiquidationService) Start(ctx context.Context) {
+	ticker := time.NewTicker(s.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.Run(ctx, s.calendar.Now()); err != nil {
+				logger.FromContext(ctx).Error("Failed to evaluate accounts", "error", err)
+			}
+		}
+	}
+}
+
+// Run evaluates every account once. It only acts during the normal session,
+// when market orders fill.
+func (s *LiquidationService) Run(ctx context.Context, now time.Time) error {
+	s.process.Lock()
+	defer s.process.Unlock()
+
+	if s.calendar.SessionAt(now) != SessionNormal {
+		return nil
+	}
+	_, end := s.calendar.NormalSessionOn(now)
+	squareOff := s.config.SquareOffBefore > 0 && !now.Before(end.Add(-s.config.SquareOffBefore))
+
+	userIDs, err := s.orders.ListUserIDs(ctx)
+	if err != nil {
+		return err
+	}
+	for _, userID := range userIDs {
+		if err := s.evaluate(ctx, userID, now, squareOff); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// evaluate liquidates the account if it is below the margin threshold, and
+// otherwise squares off its intraday positions once it is time
+func (s *LiquidationService) evaluate(ctx context.Context, userID uint, now time.Time, squareOff bool) error {
+	orders, err := s.orders.ListByUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	portfolio := s.positions.portfolio(orders)
+	margins := s.margins.margins(orders, portfolio, nil)
+
+	log := logger.FromContext(ctx).With("user_id", userID)
+	if threshold := margins.UsedMargin * s.config.MarginRatio / 100; margins.UsedMargin > 0 && margins.Equity < threshold {
+		log.Warn("Liquidating account below the margin threshold",
+			"equity", margins.Equity, "used_margin", margins.UsedMargin, "threshold", round2(threshold))
+		metrics.LiquidationsTotal.WithLabelValues("margin_call").Inc()
+		return s.liquidate(ctx, log, userID, orders, portfolio, now)
+	}
+	if !squareOff {
+		return nil
+	}
+
+	exits := intradayQuantities(orders, portfolio, startOfDay(now.In(s.calendar.Location())))
+	var open []models.Order
+	for _, order := range orders {
+		if order.IsOpen() && order.Product == models.ProductIntraday {
+			open = append(open, order)
+		}
+	}
+	if len(exits) == 0 && len(open) == 0 {
+		return nil
+	}
+	log.Info("Squaring off intraday positions", "positions", len(exits), "open_orders", len(open))
+	metrics.LiquidationsTotal.WithLabelValues("square_off").Inc()
+	if err := s.cancel(ctx, log, open); err != nil {
+		return err
+	}
+	for _, symbol := range slices.Sorted(maps.Keys(exits)) {
+		if err := s.exit(ctx, log, userID, symbol, exits[symbol], models.ProductIntraday); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// liquidate cancels every open order and closes every position at market,
+// as INTRADAY for what today's intraday fills opened and DELIVERY for the rest
+func (s *LiquidationService) liquidate(ctx context.Context, log *slog.Logger, userID uint, orders []models.Order, portfolio *Portfolio, now time.Time) error {
+	var open []models.Order
+	for _, order := range orders {
+		if order.IsOpen() {
+			open = append(open, order)
+		}
+	}
+	if err := s.cancel(ctx, log, open); err != nil {
+		return err
+	}
+	intraday := intradayQuantities(orders, portfolio, startOfDay(now.In(s.calendar.Location())))
+	for _, position := range portfolio.Positions {
+		if quantity := intraday[position.Symbol]; quantity != 0 {
+			if err := s.exit(ctx, log, userID, position.Symbol, quantity, models.ProductIntraday); err != nil {
+				return err
+			}
+		}
+		if quantity := portfolio.NetQuantity(position.Symbol) - intraday[position.Symbol]; quantity != 0 {
+			if err := s.exit(ctx, log, userID, position.Symbol, quantity, models.ProductDelivery); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// cancel cancels open orders along with their linked legs
+func (s *LiquidationService) cancel(ctx context.Context, log *slog.Logger, open []models.Order) error {
+	cancelled, err := s.engine.CancelAll(ctx, open)
+	if cancelled > 0 {
+		log.Info("Cancelled open orders", "cancelled_orders", cancelled)
+	}
+	return err
+}
+
+// exit closes a signed quantity of a position with a market order. An order
+// the market refuses, such as on a halted symbol, is logged and retried on
+// the next run.
+func (s *LiquidationService) exit(ctx context.Context, log *slog.Logger, userID uint, symbol string, quantity int, product string) error {
+	side := models.SideSell
+	if quantity < 0 {
+		side = models.SideBuy
+	}
+	order, err := s.placer.placeExit(ctx, userID, symbol, side, abs(quantity), product)
+
+	var orderErr *OrderError
+	switch {
+	case errors.As(err, &orderErr):
+		log.Error("Exit order rejected", "symbol", symbol, "side", side, "quantity", abs(quantity),
+			"code", orderErr.Code, "reason", orderErr.Message)
+		return nil
+	case err != nil:
+		return err
+	}
+	log.Warn("Placed exit order", "order_id", order.ID, "symbol", symbol, "side", side,
+		"quantity", order.Quantity, "status", order.Status, "average_price", order.AveragePrice)
+	return nil
+}
+
+// intradayQuantities returns the signed quantity to close per symbol: what
+// today's INTRADAY fills bought less what they sold, capped at the open
+// position so quantity already closed by other orders is not reopened
+func intradayQuantities(orders []models.Order, portfolio *Portfolio, dayStart time.Time) map[string]int {
+	intraday := make(map[string]int)
+	for _, order := range orders {
+		if order.Product != models.ProductIntraday || order.FilledQuantity == 0 ||
+			order.ExecutedTime == nil || order.ExecutedTime.Before(dayStart) {
+			continue
+		}
+		if order.Side == models.SideBuy {
+			intraday[order.Symbol] += order.FilledQuantity
+		} else {
+			intraday[order.Symbol] -= order.FilledQuantity
+		}
+	}
+
+	exits := make(map[string]int)
+	for symbol, quantity := range intraday {
+		net := portfolio.NetQuantity(symbol)
+		switch {
+		case quantity > 0 && net > 0:
+			exits[symbol] = min(quantity, net)
+		case quantity < 0 && net < 0:
+			exits[symbol] = max(quantity, net)
+		}
+	}
+	return exits
+}
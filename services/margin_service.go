@@ -0,0 +1,152 @@
+package services
+
+import (
+	"context"
+	"sort"
+	"trading-platform-backend/config"
+	"trading-platform-backend/models"
+	"trading-platform-backend/repository"
+)
+
+// RejectInsufficientMargin is returned when an order needs more margin than is available
+const RejectInsufficientMargin = "INSUFFICIENT_MARGIN"
+
+// MarginService computes account equity and margin. Long and short positions
+// hold their instrument's VaR + ELM rate (or the configured default) of
+// their exposure at the last price; open orders block margin for the
+// exposure they could add if they all filled. Symbols that have not traded
+// yet are valued at their open orders' own prices.
+type MarginService struct {
+	orders      repository.OrderRepository
+	positions   *PositionService
+	instruments *InstrumentService
+	prices      *PriceSimulator
+	config      config.MarginConfig
+}
+
+func NewMarginService(orders repository.OrderRepository, positions *PositionService, instruments *InstrumentService, prices *PriceSimulator, cfg config.MarginConfig) *MarginService {
+	return &MarginService{
+		orders:      orders,
+		positions:   positions,
+		instruments: instruments,
+		prices:      prices,
+		config:      cfg,
+	}
+}
+
+// Margins returns the user's account equity and margin usage
+func (s *MarginService) Margins(ctx context.Context, userID uint) (*models.Margins, error) {
+	orders, err := s.orders.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.margins(orders, s.positions.portfolio(orders), nil), nil
+}
+
+// Rate returns the margin rate for symbol in percent of exposure
+func (s *MarginService) Rate(symbol string) float64 {
+	if instrument, ok := s.instruments.Get(symbol); ok && instrument.VaRMargin+instrument.ELMMargin > 0 {
+		return instrument.VaRMargin + instrument.ELMMargin
+	}
+	return s.config.DefaultRate
+}
+
+// check rejects order when the margin it adds exceeds the available margin.
+// Orders that only reduce exposure always pass.
+func (s *MarginService) check(order *models.Order, orders []models.Order, portfolio *Portfolio) *OrderError {
+	if _, ok := s.prices.LastPrice(order.Symbol); !ok && order.Price == 0 && order.TriggerPrice == 0 {
+		return rejectOrder(RejectNoLastPrice, "%s has no last price to value a %s order's margin at", order.Symbol, order.OrderType)
+	}
+	before := s.margins(orders, portfolio, nil)
+	after := s.margins(orders, portfolio, order)
+	required := (after.UsedMargin + after.BlockedMargin) - (before.UsedMargin + before.BlockedMargin)
+	if required > 0 && required > before.AvailableMargin {
+		return rejectOrder(RejectInsufficientMargin, "the order needs %.2f margin but only %.2f is available", required, before.AvailableMargin)
+	}
+	return nil
+}
+
+// margins values the portfolio and the open orders, plus order if set
+func (s *MarginService) margins(orders []models.Order, portfolio *Portfolio, order *models.Order) *models.Margins {
+	type exposure struct {
+		buys, sells         int
+		buyValue, sellValue float64 // at each order's own limit or trigger price
+	}
+	pending := make(map[string]*exposure)
+	add := func(o models.Order) {
+		e, ok := pending[o.Symbol]
+		if !ok {
+			e = &exposure{}
+			pending[o.Symbol] = e
+		}
+		remaining := o.Quantity - o.FilledQuantity
+		value := float64(remaining) * max(o.Price, o.TriggerPrice)
+		if o.Side == models.SideBuy {
+			e.buys += remaining
+			e.buyValue += value
+		} else {
+			e.sells += remaining
+			e.sellValue += value
+		}
+	}
+	for _, o := range orders {
+		if o.IsOpen() {
+			add(o)
+		}
+	}
+	if order != nil {
+		add(*order)
+	}
+	for _, position := range portfolio.Positions {
+		if _, ok := pending[position.Symbol]; !ok {
+			pending[position.Symbol] = &exposure{}
+		}
+	}
+
+	margins := &models.Margins{
+		Cash:          round2(s.config.StartingCash + portfolio.RealizedPNL),
+		UnrealizedPNL: portfolio.UnrealizedPNL,
+		Symbols:       []models.SymbolMargin{},
+	}
+	margins.Equity = round2(margins.Cash + margins.UnrealizedPNL)
+
+	for symbol, e := range pending {
+		net := portfolio.NetQuantity(symbol)
+		// The worst case is every open order on one side filling
+		worst := max(abs(net), abs(net+e.buys), abs(net-e.sells))
+		last, ok := s.prices.LastPrice(symbol)
+		price := last
+		if !ok {
+			// Nothing has traded yet, so value the side that could fill
+			// furthest at the average price of its orders
+			quantity, value := e.sells, e.sellValue
+			if abs(net+e.buys) >= abs(net-e.sells) {
+				quantity, value = e.buys, e.buyValue
+			}
+			if quantity > 0 {
+				price = value / float64(quantity)
+			}
+		}
+		rate := s.Rate(symbol)
+
+		m := models.SymbolMargin{
+			Symbol:        symbol,
+			Quantity:      net,
+			MarginRate:    rate,
+			LastPrice:     last,
+			UsedMargin:    round2(float64(abs(net)) * price * rate / 100),
+			BlockedMargin: round2(float64(worst-abs(net)) * price * rate / 100),
+		}
+		margins.UsedMargin += m.UsedMargin
+		margins.BlockedMargin += m.BlockedMargin
+		margins.Symbols = append(margins.Symbols, m)
+	}
+	sort.Slice(margins.Symbols, func(i, j int) bool { return margins.Symbols[i].Symbol < margins.Symbols[j].Symbol })
+
+	margins.UsedMargin = round2(margins.UsedMargin)
+	margins.BlockedMargin = round2(margins.BlockedMargin)
+	margins.AvailableMargin = round2(margins.Equity - margins.UsedMargin - margins.BlockedMargin)
+	margins.MaintenanceMargin = round2(margins.UsedMargin * s.config.MaintenanceRatio / 100)
+	margins.MarginCall = margins.UsedMargin > 0 && margins.Equity < margins.MaintenanceMargin
+	return margins
+}
@@ -0,0 +1,268 @@
+package services
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+	"trading-platform-backend/config"
+	"trading-platform-backend/data"
+	"trading-platform-backend/models"
+
+	// Embed the time zone database so the exchange zone resolves in minimal containers
+	_ "time/tzdata"
+)
+
+// Market sessions
+const (
+	SessionPreOpen = "PRE_OPEN"
+	SessionNormal  = "NORMAL"
+	SessionClosing = "CLOSING"
+	SessionClosed  = "CLOSED"
+)
+
+// marketSession is a window of the trading day in exchange local time
+type marketSession struct {
+	name       string
+	start, end time.Duration // offsets from local midnight
+}
+
+// nseSessions is the NSE equity trading day. The gap between 15:30 and 15:40
+// is used by the exchange to compute the closing price.
+var nseSessions = []marketSession{
+	{SessionPreOpen, 9 * time.Hour, 9*time.Hour + 15*time.Minute},
+	{SessionNormal, 9*time.Hour + 15*time.Minute, 15*time.Hour + 30*time.Minute},
+	{SessionClosing, 15*time.Hour + 40*time.Minute, 16 * time.Hour},
+}
+
+// MarketCalendar tells which exchange session is in effect at a given time,
+// accounting for weekends and exchange holidays
+type MarketCalendar struct {
+	location   *time.Location
+	holidays   map[string]string // YYYY-MM-DD -> description
+	alwaysOpen bool
+
+	mu    sync.RWMutex
+	clock func() time.Time
+}
+
+func NewMarketCalendar(location *time.Location, holidays map[string]string, alwaysOpen bool) *MarketCalendar {
+	return &MarketCalendar{
+		location:   location,
+		holidays:   holidays,
+		alwaysOpen: alwaysOpen,
+		clock:      time.Now,
+	}
+}
+
+// LoadMarketCalendar builds the calendar from configuration, reading holidays
+// from the configured file or the bundled list
+func LoadMarketCalendar(cfg config.MarketConfig) (*MarketCalendar, error) {
+	location, err := time.LoadLocation(cfg.Timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	var source io.Reader = bytes.NewReader(data.HolidaysCSV)
+	if cfg.HolidaysFile != "" {
+		f, err := os.Open(cfg.HolidaysFile)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		source = f
+	}
+
+	holidays, err := ParseHolidaysCSV(source)
+	if err != nil {
+		return nil, err
+	}
+	return NewMarketCalendar(location, holidays, cfg.AlwaysOpen), nil
+}
+
+// SetClock replaces the calendar's time source, for tests and replays
+func (c *MarketCalendar) SetClock(clock func() time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.clock = clock
+}
+
+// Now returns the current time according to the calendar's clock
+func (c *MarketCalendar) Now() time.Time {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.clock()
+}
+
+// AlwaysOpen reports whether sessions and holidays are ignored, so every
+// moment is in the normal session
+func (c *MarketCalendar) AlwaysOpen() bool {
+	return c.alwaysOpen
+}
+
+// Location returns the exchange time zone
+func (c *MarketCalendar) Location() *time.Location {
+	return c.location
+}
+
+// Holiday returns the holiday description if t falls on an exchange holiday
+func (c *MarketCalendar) Holiday(t time.Time) (string, bool) {
+	name, ok := c.holidays[t.In(c.location).Format(time.DateOnly)]
+	return name, ok
+}
+
+// IsTradingDay reports whether the exchange is open at all on t's local date
+func (c *MarketCalendar) IsTradingDay(t time.Time) bool {
+	if c.alwaysOpen {
+		return true
+	}
+	local := t.In(c.location)
+	if local.Weekday() == time.Saturday || local.Weekday() == time.Sunday {
+		return false
+	}
+	_, holiday := c.Holiday(local)
+	return !holiday
+}
+
+// SessionAt returns the session in effect at t
+func (c *MarketCalendar) SessionAt(t time.Time) string {
+	if c.alwaysOpen {
+		return SessionNormal
+	}
+	if !c.IsTradingDay(t) {
+		return SessionClosed
+	}
+
+	local := t.In(c.location)
+	offset := local.Sub(startOfDay(local))
+	for _, session := range nseSessions {
+		if offset >= session.start && offset < session.end {
+			return session.name
+		}
+	}
+	return SessionClosed
+}
+
+// Session returns the session in effect now
+func (c *MarketCalendar) Session() string {
+	return c.SessionAt(c.Now())
+}
+
+// AcceptsOrders reports whether orders may be placed during session. Orders
+// placed in pre-open stay pending until the normal session starts.
+func AcceptsOrders(session string) bool {
+	return session == SessionPreOpen || session == SessionNormal || session == SessionClosing
+}
+
+// nextSessionStart finds the first session to start after t
+func (c *MarketCalendar) nextSessionStart(t time.Time) (string, time.Time, bool) {
+	if c.alwaysOpen {
+		return "", time.Time{}, false
+	}
+
+	day := startOfDay(t.In(c.location))
+	// Long holiday stretches never exceed a couple of weeks
+	for i := 0; i < 15; i++ {
+		date := day.AddDate(0, 0, i)
+		if !c.IsTradingDay(date) {
+			continue
+		}
+		for _, session := range nseSessions {
+			if start := date.Add(session.start); start.After(t) {
+				return session.name, start, true
+			}
+		}
+	}
+	return "", time.Time{}, false
+}
+
+// CloseOn returns the end of the last session on t's exchange-local date
+func (c *MarketCalendar) CloseOn(t time.Time) time.Time {
+	last := nseSessions[len(nseSessions)-1]
+	return startOfDay(t.In(c.location)).Add(last.end)
+}
+
+// NormalSessionOn returns the start and end of the normal session on t's
+// exchange-local date
+func (c *MarketCalendar) NormalSessionOn(t time.Time) (start, end time.Time) {
+	day := startOfDay(t.In(c.location))
+	for _, session := range nseSessions {
+		if session.name == SessionNormal {
+			return day.Add(session.start), day.Add(session.end)
+		}
+	}
+	return day, day
+}
+
+// Status describes the market at the calendar's current time
+func (c *MarketCalendar) Status() models.MarketStatus {
+	now := c.Now().In(c.location)
+	session := c.SessionAt(now)
+
+	status := models.MarketStatus{
+		Session:         session,
+		AcceptingOrders: AcceptsOrders(session),
+		Trading:         session == SessionNormal,
+		Timezone:        c.location.String(),
+		Time:            now,
+	}
+	if name, ok := c.Holiday(now); ok && !c.alwaysOpen {
+		status.Holiday = name
+	}
+	if next, start, ok := c.nextSessionStart(now); ok {
+		status.NextSession = next
+		status.NextSessionStart = &start
+	}
+	return status
+}
+
+// startOfDay returns local midnight of t's date
+func startOfDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
+
+// ParseHolidaysCSV reads a holiday list with a date,description header where
+// dates are YYYY-MM-DD
+func ParseHolidaysCSV(r io.Reader) (map[string]string, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+
+	if _, err := reader.Read(); err != nil {
+		return nil, fmt.Errorf("read header: %w", err)
+	}
+
+	holidays := make(map[string]string)
+	var problems []string
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		date := strings.TrimSpace(record[0])
+		if _, err := time.Parse(time.DateOnly, date); err != nil {
+			problems = append(problems, fmt.Sprintf("line %d: invalid date %q (expected YYYY-MM-DD)", line, date))
+			continue
+		}
+		description := "Exchange holiday"
+		if len(record) > 1 && strings.TrimSpace(record[1]) != "" {
+			description = strings.TrimSpace(record[1])
+		}
+		holidays[date] = description
+	}
+
+	if len(problems) > 0 {
+		return nil, fmt.Errorf("invalid holidays file:\n  %s", strings.Join(problems, "\n  "))
+	}
+	return holidays, nil
+}
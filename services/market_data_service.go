@@ -0,0 +1,184 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+	"trading-platform-backend/models"
+	"trading-platform-backend/repository"
+)
+
+const (
+	// DefaultDepthLevels is the number of price levels returned per side
+	DefaultDepthLevels = 5
+	// MaxDepthLevels caps the levels a client may request
+	MaxDepthLevels = 20
+
+	// syntheticLotsPerLevel sizes the simulated market maker's quotes; the
+	// quantity at level n is n times this many lots
+	syntheticLotsPerLevel = 100
+)
+
+// ErrUnknownSymbol is returned for symbols missing from the instrument master
+var ErrUnknownSymbol = errors.New("unknown symbol")
+
+// MarketDataService builds quotes and market depth from the simulated price
+// feed and the resting orders in the order book
+type MarketDataService struct {
+	instruments *InstrumentService
+	prices      *PriceSimulator
+	orders      repository.OrderRepository
+}
+
+func NewMarketDataService(instruments *InstrumentService, prices *PriceSimulator, orders repository.OrderRepository) *MarketDataService {
+	return &MarketDataService{
+		instruments: instruments,
+		prices:      prices,
+		orders:      orders,
+	}
+}
+
+// GetQuotes returns a quote per symbol, in request order
+func (s *MarketDataService) GetQuotes(ctx context.Context, symbols []string) ([]models.Quote, error) {
+	quotes := make([]models.Quote, 0, len(symbols))
+	for _, symbol := range symbols {
+		quote, err := s.GetQuote(ctx, symbol)
+		if err != nil {
+			return nil, err
+		}
+		quotes = append(quotes, *quote)
+	}
+	return quotes, nil
+}
+
+// GetQuote returns the latest price, best bid/ask and session summary for symbol
+func (s *MarketDataService) GetQuote(ctx context.Context, symbol string) (*models.Quote, error) {
+	depth, err := s.GetDepth(ctx, symbol, 1)
+	if err != nil {
+		return nil, err
+	}
+	snapshot, _ := s.prices.Snapshot(depth.Symbol)
+
+	quote := &models.Quote{
+		Symbol:    depth.Symbol,
+		LastPrice: snapshot.LastPrice,
+		Open:      snapshot.Open,
+		High:      snapshot.High,
+		Low:       snapshot.Low,
+		PrevClose: snapshot.PrevClose,
+		Volume:    snapshot.Volume,
+		Timestamp: depth.Timestamp,
+	}
+	if lower, upper, ok := s.prices.Band(depth.Symbol); ok {
+		quote.LowerBand, quote.UpperBand = lower, upper
+	}
+	_, quote.Halted = s.prices.HaltedUntil(depth.Symbol)
+	if snapshot.PrevClose > 0 {
+		quote.Change = round2(snapshot.LastPrice - snapshot.PrevClose)
+		quote.ChangePercent = round2((snapshot.LastPrice - snapshot.PrevClose) / snapshot.PrevClose * 100)
+	}
+	if len(depth.Bids) > 0 {
+		quote.Bid, quote.BidQuantity = depth.Bids[0].Price, depth.Bids[0].Quantity
+	}
+	if len(depth.Asks) > 0 {
+		quote.Ask, quote.AskQuantity = depth.Asks[0].Price, depth.Asks[0].Quantity
+	}
+	return quote, nil
+}
+
+// GetDepth aggregates pending orders by price and merges them with the
+// simulated market maker's quotes around the last price, returning the best
+// levels on each side
+func (s *MarketDataService) GetDepth(ctx context.Context, symbol string, levels int) (*models.MarketDepth, error) {
+	instrument, ok := s.instruments.Get(symbol)
+	if !ok {
+		return nil, fmt.Errorf("%w %q", ErrUnknownSymbol, symbol)
+	}
+
+	orders, err := s.orders.ListOpenBySymbol(ctx, instrument.Symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	bids := make(map[float64]*models.DepthLevel)
+	asks := make(map[float64]*models.DepthLevel)
+	add := func(book map[float64]*models.DepthLevel, price float64, quantity int) {
+		level, ok := book[price]
+		if !ok {
+			level = &models.DepthLevel{Price: price}
+			book[price] = level
+		}
+		level.Quantity += quantity
+		level.Orders++
+	}
+
+	// Only resting limit prices are visible; stop and market orders are not
+	for _, order := range orders {
+		if order.Status != models.OrderStatusPending || order.Price <= 0 {
+			continue
+		}
+		if order.Side == models.SideBuy {
+			add(bids, order.Price, order.Quantity)
+		} else {
+			add(asks, order.Price, order.Quantity)
+		}
+	}
+
+	// Market maker liquidity only exists while the instrument trades
+	if last, ok := s.prices.LastPrice(instrument.Symbol); ok && instrument.TradingStatus == models.InstrumentActive {
+		tick := instrument.TickSize
+		if tick <= 0 {
+			tick = 0.01
+		}
+		for n := 1; n <= levels; n++ {
+			quantity := syntheticLevelQuantity(instrument, n)
+			if bid := roundToTick(last-float64(n)*tick, tick); bid > 0 {
+				add(bids, bid, quantity)
+			}
+			add(asks, roundToTick(last+float64(n)*tick, tick), quantity)
+		}
+	}
+
+	return &models.MarketDepth{
+		Symbol:    instrument.Symbol,
+		Bids:      bestLevels(bids, levels, true),
+		Asks:      bestLevels(asks, levels, false),
+		Timestamp: time.Now(),
+	}, nil
+}
+
+// syntheticLevelQuantity is the market maker's quoted size n levels away
+// from the last price
+func syntheticLevelQuantity(instrument models.Instrument, n int) int {
+	return n * syntheticLotsPerLevel * max(instrument.LotSize, 1)
+}
+
+// syntheticDepthQuantity is the total size the market maker quotes on one
+// side of the default depth; it bounds what an order can fill immediately
+func syntheticDepthQuantity(instrument models.Instrument) int {
+	total := 0
+	for n := 1; n <= DefaultDepthLevels; n++ {
+		total += syntheticLevelQuantity(instrument, n)
+	}
+	return total
+}
+
+// bestLevels sorts a book side best price first and keeps the top n
+func bestLevels(book map[float64]*models.DepthLevel, n int, descending bool) []models.DepthLevel {
+	levels := make([]models.DepthLevel, 0, len(book))
+	for _, level := range book {
+		levels = append(levels, *level)
+	}
+	sort.Slice(levels, func(i, j int) bool {
+		if descending {
+			return levels[i].Price > levels[j].Price
+		}
+		return levels[i].Price < levels[j].Price
+	})
+	if len(levels) > n {
+		levels = levels[:n]
+	}
+	return levels
+}
@@ -0,0 +1,525 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"trading-platform-backend/config"
+	"trading-platform-backend/models"
+	"trading-platform-backend/repository"
+)
+
+// Order rejection codes returned to clients in ErrorResponse.Code
+const (
+	RejectUnknownSymbol  = "UNKNOWN_SYMBOL"
+	RejectNotTradable    = "INSTRUMENT_NOT_TRADABLE"
+	RejectInvalidLotSize = "INVALID_LOT_SIZE"
+	RejectTradingHalted  = "TRADING_HALTED"
+	RejectMarketClosed   = "MARKET_CLOSED"
+	RejectInvalidTick    = "INVALID_TICK_SIZE"
+	RejectPriceBand      = "PRICE_OUT_OF_BAND"
+	RejectNoLastPrice    = "NO_LAST_PRICE"
+
+	RejectPriceRequired     = "PRICE_REQUIRED"
+	RejectPriceNotAllowed   = "PRICE_NOT_ALLOWED"
+	RejectTriggerRequired   = "TRIGGER_PRICE_REQUIRED"
+	RejectTriggerNotAllowed = "TRIGGER_PRICE_NOT_ALLOWED"
+	RejectInvalidTrigger    = "INVALID_TRIGGER_PRICE"
+	RejectTrailRequired     = "TRAIL_REQUIRED"
+	RejectTrailNotAllowed   = "TRAIL_NOT_ALLOWED"
+	RejectInvalidTrail      = "INVALID_TRAIL"
+
+	RejectInvalidTimeInForce = "INVALID_TIME_IN_FORCE"
+	RejectInvalidExpiry      = "INVALID_EXPIRY_DATE"
+
+	RejectInvalidLegPrice = "INVALID_LEG_PRICE"
+)
+
+// maxGTDDays is how far ahead a good-till-date order may expire
+const maxGTDDays = 365
+
+// OrderError is a business rejection of an order with a machine-readable code
+type OrderError struct {
+	Code    string
+	Message string
+}
+
+func (e *OrderError) Error() string {
+	return e.Message
+}
+
+func rejectOrder(code, format string, args ...any) *OrderError {
+	return &OrderError{Code: code, Message: fmt.Sprintf(format, args...)}
+}
+
+var (
+	// ErrOrderNotFound is returned when an order does not exist or belongs to another user
+	ErrOrderNotFound = errors.New("order not found")
+	// ErrOrderNotCancellable is returned when an order has nothing left to cancel
+	ErrOrderNotCancellable = errors.New("order is no longer open")
+)
+
+// OrderService validates orders and hands them to the execution engine
+type OrderService struct {
+	orders      repository.OrderRepository
+	engine      *ExecutionEngine
+	halts       *HaltService
+	risk        *RiskService
+	instruments *InstrumentService
+	prices      *PriceSimulator
+	calendar    *MarketCalendar
+	cfgManager  *config.Manager
+
+	placing sync.Map // user ID -> *sync.Mutex held from pre-trade checks to submission
+}
+
+func NewOrderService(orders repository.OrderRepository, engine *ExecutionEngine, halts *HaltService, risk *RiskService, instruments *InstrumentService, prices *PriceSimulator, calendar *MarketCalendar, cfgManager *config.Manager) *OrderService {
+	return &OrderService{
+		orders:      orders,
+		engine:      engine,
+		halts:       halts,
+		risk:        risk,
+		instruments: instruments,
+		prices:      prices,
+		calendar:    calendar,
+		cfgManager:  cfgManager,
+	}
+}
+
+// PlaceOrder validates the request against the instrument master, the
+// market session, trading halts and kill switches, price rules and the
+// user's risk limits, then submits it for execution. Orders placed during
+// pre-open wait for the normal session.
+func (s *OrderService) PlaceOrder(ctx context.Context, userID uint, req models.PlaceOrderRequest) (*models.Order, error) {
+	return s.placeOrder(ctx, userID, req, "")
+}
+
+// placeOrder places a regular order, as a slice of the algo order algoID if set
+func (s *OrderService) placeOrder(ctx context.Context, userID uint, req models.PlaceOrderRequest, algoID string) (*models.Order, error) {
+	if req.TimeInForce == "" {
+		req.TimeInForce = models.TimeInForceDay
+	}
+	if req.Product == "" {
+		req.Product = models.ProductDelivery
+	}
+	instrument, err := s.validate(req)
+	if err != nil {
+		return nil, err
+	}
+	expiresAt, err := s.expiry(req)
+	if err != nil {
+		return nil, err
+	}
+
+	order := s.newOrder(userID, instrument, req, expiresAt)
+	order.OrderClass = models.OrderClassRegular
+	order.AlgoID = algoID
+	if order.OrderType == models.OrderTypeTrailing {
+		last, ok := s.prices.LastPrice(instrument.Symbol)
+		if !ok {
+			return nil, rejectOrder(RejectNoLastPrice, "%s has no last price to trail from", instrument.Symbol)
+		}
+		if order.TriggerPrice = TrailingTrigger(order, last, instrument.TickSize); order.TriggerPrice <= 0 {
+			return nil, rejectOrder(RejectInvalidTrail, "trail must be smaller than the last price %g", last)
+		}
+	}
+	if err := s.submit(ctx, userID, order); err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+// PlaceBracketOrder places an entry order with a target and a stop-loss exit
+// leg on the opposite side. The exits are held until the entry fills, then
+// work as an OCO pair for the filled quantity. Brackets are intraday.
+func (s *OrderService) PlaceBracketOrder(ctx context.Context, userID uint, req models.BracketOrderRequest) (*models.Order, error) {
+	exitSide := models.SideSell
+	if req.Side == models.SideSell {
+		exitSide = models.SideBuy
+	}
+	entryReq := models.PlaceOrderRequest{
+		Symbol: req.Symbol, Side: req.Side, OrderType: req.OrderType,
+		Quantity: req.Quantity, Price: req.Price, TimeInForce: models.TimeInForceDay, Product: models.ProductIntraday,
+	}
+	targetReq := models.PlaceOrderRequest{
+		Symbol: req.Symbol, Side: exitSide, OrderType: models.OrderTypeLimit,
+		Quantity: req.Quantity, Price: req.TargetPrice, TimeInForce: models.TimeInForceDay, Product: models.ProductIntraday,
+	}
+	stopReq := models.PlaceOrderRequest{
+		Symbol: req.Symbol, Side: exitSide, OrderType: models.OrderTypeStopLoss,
+		Quantity: req.Quantity, TriggerPrice: req.StopLossPrice, TimeInForce: models.TimeInForceDay, Product: models.ProductIntraday,
+	}
+
+	instrument, err := s.validate(entryReq)
+	if err != nil {
+		return nil, err
+	}
+	for _, leg := range []models.PlaceOrderRequest{targetReq, stopReq} {
+		if _, err := s.validate(leg); err != nil {
+			return nil, err
+		}
+	}
+
+	// The exits must sit either side of where the entry fills
+	entryPrice := req.Price
+	if req.OrderType == models.OrderTypeMarket {
+		last, ok := s.prices.LastPrice(instrument.Symbol)
+		if !ok {
+			return nil, rejectOrder(RejectNoLastPrice, "%s has no last price to place the exits around; use a limit entry", instrument.Symbol)
+		}
+		entryPrice = last
+	}
+	if req.Side == models.SideBuy && (req.TargetPrice <= entryPrice || req.StopLossPrice >= entryPrice) {
+		return nil, rejectOrder(RejectInvalidLegPrice, "a buy bracket needs target above and stop-loss below the entry price %g", entryPrice)
+	}
+	if req.Side == models.SideSell && (req.TargetPrice >= entryPrice || req.StopLossPrice <= entryPrice) {
+		return nil, rejectOrder(RejectInvalidLegPrice, "a sell bracket needs target below and stop-loss above the entry price %g", entryPrice)
+	}
+
+	expiresAt, err := s.expiry(entryReq)
+	if err != nil {
+		return nil, err
+	}
+	entry := s.newOrder(userID, instrument, entryReq, expiresAt)
+	target := s.newOrder(userID, instrument, targetReq, expiresAt)
+	stop := s.newOrder(userID, instrument, stopReq, expiresAt)
+	entry.OrderClass, entry.Leg = models.OrderClassBracket, models.LegEntry
+	target.OrderClass, target.Leg, target.ParentID = models.OrderClassBracket, models.LegTarget, entry.ID
+	stop.OrderClass, stop.Leg, stop.ParentID = models.OrderClassBracket, models.LegStopLoss, entry.ID
+
+	// The exits only ever close what the entry opens
+	if err := s.submit(ctx, userID, entry, target, stop); err != nil {
+		return nil, err
+	}
+	entry.Legs = []models.Order{*target, *stop}
+	return entry, nil
+}
+
+// PlaceOCOOrder places a target limit order and a stop-loss order on the same
+// side, typically to exit a position; whichever fills first cancels the
+// other. The target leg is the parent of the stop-loss leg.
+func (s *OrderService) PlaceOCOOrder(ctx context.Context, userID uint, req models.OCOOrderRequest) (*models.Order, error) {
+	if req.TimeInForce == "" {
+		req.TimeInForce = models.TimeInForceDay
+	}
+	if req.Product == "" {
+		req.Product = models.ProductDelivery
+	}
+	targetReq := models.PlaceOrderRequest{
+		Symbol: req.Symbol, Side: req.Side, OrderType: models.OrderTypeLimit, Quantity: req.Quantity,
+		Price: req.TargetPrice, TimeInForce: req.TimeInForce, ExpireDate: req.ExpireDate, Product: req.Product,
+	}
+	stopReq := models.PlaceOrderRequest{
+		Symbol: req.Symbol, Side: req.Side, OrderType: models.OrderTypeStopLoss, Quantity: req.Quantity,
+		TriggerPrice: req.StopLossPrice, TimeInForce: req.TimeInForce, ExpireDate: req.ExpireDate, Product: req.Product,
+	}
+
+	instrument, err := s.validate(targetReq)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.validate(stopReq); err != nil {
+		return nil, err
+	}
+
+	// The target must not be marketable, or the pair would resolve at once
+	if last, ok := s.prices.LastPrice(instrument.Symbol); ok {
+		if req.Side == models.SideBuy && req.TargetPrice >= last {
+			return nil, rejectOrder(RejectInvalidLegPrice, "target price of a buy OCO must be below the last price %g", last)
+		}
+		if req.Side == models.SideSell && req.TargetPrice <= last {
+			return nil, rejectOrder(RejectInvalidLegPrice, "target price of a sell OCO must be above the last price %g", last)
+		}
+	}
+
+	expiresAt, err := s.expiry(targetReq)
+	if err != nil {
+		return nil, err
+	}
+	target := s.newOrder(userID, instrument, targetReq, expiresAt)
+	stop := s.newOrder(userID, instrument, stopReq, expiresAt)
+	target.OrderClass, target.Leg = models.OrderClassOCO, models.LegTarget
+	stop.OrderClass, stop.Leg, stop.ParentID = models.OrderClassOCO, models.LegStopLoss, target.ID
+
+	// At most one leg fills, so the pair is checked as its target
+	if err := s.submit(ctx, userID, target, stop); err != nil {
+		return nil, err
+	}
+	target.Legs = []models.Order{*stop}
+	return target, nil
+}
+
+// CancelOrder cancels one of the user's open orders and any legs linked to it
+func (s *OrderService) CancelOrder(ctx context.Context, userID uint, orderID string) (*models.Order, error) {
+	if _, err := s.GetOrder(ctx, userID, orderID); err != nil {
+		return nil, err
+	}
+	order, err := s.engine.Cancel(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	return order, s.attachLegs(ctx, order)
+}
+
+// placeExit places a market order on the platform's behalf that reduces the
+// user's position in symbol. Exits skip the risk and margin checks, which
+// must never stand in the way of closing exposure, but respect kill switches
+// like any other order.
+func (s *OrderService) placeExit(ctx context.Context, userID uint, symbol, side string, quantity int, product string) (*models.Order, error) {
+	req := models.PlaceOrderRequest{
+		Symbol: symbol, Side: side, OrderType: models.OrderTypeMarket,
+		Quantity: quantity, TimeInForce: models.TimeInForceDay, Product: product,
+	}
+	instrument, err := s.validate(req)
+	if err != nil {
+		return nil, err
+	}
+	expiresAt, err := s.expiry(req)
+	if err != nil {
+		return nil, err
+	}
+
+	order := s.newOrder(userID, instrument, req, expiresAt)
+	order.OrderClass = models.OrderClassRegular
+	if err := s.halts.Check(ctx, userID, symbol); err != nil {
+		return nil, err
+	}
+	if err := s.engine.Submit(ctx, order); err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+// submit runs the pre-trade checks on order and submits it with its legs.
+// Each user's orders go through one at a time, so concurrent orders cannot
+// both pass on the same available margin or open order slot.
+func (s *OrderService) submit(ctx context.Context, userID uint, order *models.Order, legs ...*models.Order) error {
+	lock, _ := s.placing.LoadOrStore(userID, &sync.Mutex{})
+	lock.(*sync.Mutex).Lock()
+	defer lock.(*sync.Mutex).Unlock()
+
+	if err := s.preTrade(ctx, userID, order); err != nil {
+		return err
+	}
+	return s.engine.Submit(ctx, order, legs...)
+}
+
+// preTrade runs the kill switch and risk checks on an order about to be submitted
+func (s *OrderService) preTrade(ctx context.Context, userID uint, order *models.Order) error {
+	if err := s.halts.Check(ctx, userID, order.Symbol); err != nil {
+		return err
+	}
+	return s.risk.Check(ctx, userID, order)
+}
+
+func (s *OrderService) newOrder(userID uint, instrument models.Instrument, req models.PlaceOrderRequest, expiresAt *time.Time) *models.Order {
+	return &models.Order{
+		ID:           NewOrderID(),
+		UserID:       userID,
+		Symbol:       instrument.Symbol,
+		Side:         req.Side,
+		OrderType:    req.OrderType,
+		Quantity:     req.Quantity,
+		Price:        req.Price,
+		TriggerPrice: req.TriggerPrice,
+		TrailAmount:  req.TrailAmount,
+		TrailPercent: req.TrailPercent,
+		TimeInForce:  req.TimeInForce,
+		ExpiresAt:    expiresAt,
+		OrderTime:    s.calendar.Now(),
+		Product:      req.Product,
+	}
+}
+
+// attachLegs fills in the legs of a bracket or OCO parent order
+func (s *OrderService) attachLegs(ctx context.Context, order *models.Order) error {
+	if order.ParentID != "" || (order.OrderClass != models.OrderClassBracket && order.OrderClass != models.OrderClassOCO) {
+		return nil
+	}
+	legs, err := s.orders.ListByParent(ctx, order.ID)
+	order.Legs = legs
+	return err
+}
+
+// GetOrder returns one of the user's orders, with its legs if it is the
+// parent of a bracket or OCO order
+func (s *OrderService) GetOrder(ctx context.Context, userID uint, orderID string) (*models.Order, error) {
+	order, err := s.orders.GetByID(ctx, orderID)
+	if errors.Is(err, repository.ErrNotFound) || (err == nil && order.UserID != userID) {
+		return nil, ErrOrderNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return order, s.attachLegs(ctx, order)
+}
+
+func (s *OrderService) validate(req models.PlaceOrderRequest) (models.Instrument, error) {
+	instrument, ok := s.instruments.Get(req.Symbol)
+	if !ok {
+		return instrument, rejectOrder(RejectUnknownSymbol, "unknown symbol %q", req.Symbol)
+	}
+	if instrument.TradingStatus == models.InstrumentHalted {
+		return instrument, rejectOrder(RejectTradingHalted, "trading is halted for %s", instrument.Symbol)
+	}
+	if instrument.TradingStatus != models.InstrumentActive {
+		return instrument, rejectOrder(RejectNotTradable, "%s is not tradable (status %s)", instrument.Symbol, instrument.TradingStatus)
+	}
+	if session := s.calendar.Session(); !AcceptsOrders(session) {
+		return instrument, rejectOrder(RejectMarketClosed, "the market is closed (session %s)", session)
+	}
+	if s.cfgManager.Current().TradingConfig.IsSymbolHalted(instrument.Symbol) {
+		return instrument, rejectOrder(RejectTradingHalted, "trading is halted for %s", instrument.Symbol)
+	}
+	if until, halted := s.prices.HaltedUntil(instrument.Symbol); halted {
+		return instrument, rejectOrder(RejectTradingHalted, "%s hit its price band; trading resumes at %s",
+			instrument.Symbol, until.In(s.calendar.Location()).Format("15:04"))
+	}
+	if req.Quantity%instrument.LotSize != 0 {
+		return instrument, rejectOrder(RejectInvalidLotSize, "quantity must be a multiple of the lot size %d", instrument.LotSize)
+	}
+	if err := validatePriceFields(req); err != nil {
+		return instrument, err
+	}
+	if req.TrailAmount > 0 && !OnTick(req.TrailAmount, instrument.TickSize) {
+		return instrument, rejectOrder(RejectInvalidTick, "trail amount %g is not a multiple of the tick size %g", req.TrailAmount, instrument.TickSize)
+	}
+	if err := s.validateTimeInForce(req, instrument.Symbol); err != nil {
+		return instrument, err
+	}
+
+	lower, upper, banded := s.prices.Band(instrument.Symbol)
+	for _, p := range []struct {
+		name  string
+		value float64
+	}{{"price", req.Price}, {"trigger price", req.TriggerPrice}} {
+		if p.value == 0 {
+			continue
+		}
+		if !OnTick(p.value, instrument.TickSize) {
+			return instrument, rejectOrder(RejectInvalidTick, "%s %g is not a multiple of the tick size %g", p.name, p.value, instrument.TickSize)
+		}
+		if banded && (p.value < lower || p.value > upper) {
+			return instrument, rejectOrder(RejectPriceBand, "%s %g is outside today's band %g - %g", p.name, p.value, lower, upper)
+		}
+	}
+
+	// A stop must sit on the far side of the market, or it would fire at once
+	if req.TriggerPrice > 0 {
+		if last, ok := s.prices.LastPrice(instrument.Symbol); ok {
+			if req.Side == models.SideBuy && req.TriggerPrice <= last {
+				return instrument, rejectOrder(RejectInvalidTrigger, "trigger price for a buy stop must be above the last price %g", last)
+			}
+			if req.Side == models.SideSell && req.TriggerPrice >= last {
+				return instrument, rejectOrder(RejectInvalidTrigger, "trigger price for a sell stop must be below the last price %g", last)
+			}
+		}
+	}
+	return instrument, nil
+}
+
+// validatePriceFields checks which of price, trigger price and trail the
+// order type requires or forbids. A trailing stop's trigger is derived from
+// its trail.
+func validatePriceFields(req models.PlaceOrderRequest) error {
+	needsPrice := req.OrderType == models.OrderTypeLimit || req.OrderType == models.OrderTypeStopLimit
+	needsTrigger := req.OrderType == models.OrderTypeStopLimit || req.OrderType == models.OrderTypeStopLoss
+	trailing := req.OrderType == models.OrderTypeTrailing
+
+	switch {
+	case needsPrice && req.Price == 0:
+		return rejectOrder(RejectPriceRequired, "%s orders require a price", req.OrderType)
+	case !needsPrice && req.Price != 0:
+		return rejectOrder(RejectPriceNotAllowed, "%s orders execute at the market and must not set a price", req.OrderType)
+	case needsTrigger && req.TriggerPrice == 0:
+		return rejectOrder(RejectTriggerRequired, "%s orders require a trigger price", req.OrderType)
+	case !needsTrigger && req.TriggerPrice != 0:
+		return rejectOrder(RejectTriggerNotAllowed, "%s orders must not set a trigger price", req.OrderType)
+	case trailing && (req.TrailAmount == 0) == (req.TrailPercent == 0):
+		return rejectOrder(RejectTrailRequired, "TSL orders require exactly one of trail_amount and trail_percent")
+	case !trailing && (req.TrailAmount != 0 || req.TrailPercent != 0):
+		return rejectOrder(RejectTrailNotAllowed, "%s orders must not set a trail", req.OrderType)
+	}
+
+	// The limit of a stop-limit order must leave room to fill once triggered
+	if req.OrderType == models.OrderTypeStopLimit {
+		if req.Side == models.SideBuy && req.Price < req.TriggerPrice {
+			return rejectOrder(RejectInvalidTrigger, "trigger price of a buy stop-limit must not exceed its price")
+		}
+		if req.Side == models.SideSell && req.Price > req.TriggerPrice {
+			return rejectOrder(RejectInvalidTrigger, "trigger price of a sell stop-limit must not be below its price")
+		}
+	}
+	return nil
+}
+
+// validateTimeInForce checks the time in force fits the order type and
+// session. Immediate orders also need a last price to be quoted against.
+func (s *OrderService) validateTimeInForce(req models.PlaceOrderRequest, symbol string) error {
+	immediate := req.TimeInForce == models.TimeInForceIOC || req.TimeInForce == models.TimeInForceFOK
+	stop := models.IsStopOrderType(req.OrderType)
+	_, priced := s.prices.LastPrice(symbol)
+
+	switch {
+	case immediate && stop:
+		return rejectOrder(RejectInvalidTimeInForce, "%s orders cannot be %s", req.OrderType, req.TimeInForce)
+	case immediate && s.calendar.Session() != SessionNormal:
+		return rejectOrder(RejectInvalidTimeInForce, "%s orders are only accepted during the normal session", req.TimeInForce)
+	case immediate && !priced:
+		return rejectOrder(RejectNoLastPrice, "%s has no last price to quote %s orders against", symbol, req.TimeInForce)
+	case req.OrderType == models.OrderTypeMarket && (req.TimeInForce == models.TimeInForceGTC || req.TimeInForce == models.TimeInForceGTD):
+		return rejectOrder(RejectInvalidTimeInForce, "MARKET orders cannot be %s", req.TimeInForce)
+	case req.TimeInForce != models.TimeInForceGTD && req.ExpireDate != "":
+		return rejectOrder(RejectInvalidExpiry, "expire_date is only allowed for GTD orders")
+	}
+	return nil
+}
+
+// expiry returns when an order with the request's time in force expires:
+// DAY at today's close, GTD at the close of its expiry date, others never
+func (s *OrderService) expiry(req models.PlaceOrderRequest) (*time.Time, error) {
+	now := s.calendar.Now()
+
+	switch req.TimeInForce {
+	case models.TimeInForceDay:
+		closeAt := s.calendar.CloseOn(now)
+		return &closeAt, nil
+	case models.TimeInForceGTD:
+		if req.ExpireDate == "" {
+			return nil, rejectOrder(RejectInvalidExpiry, "GTD orders require an expire_date")
+		}
+		date, err := time.ParseInLocation(time.DateOnly, req.ExpireDate, s.calendar.Location())
+		if err != nil {
+			return nil, rejectOrder(RejectInvalidExpiry, "expire_date must be YYYY-MM-DD")
+		}
+		closeAt := s.calendar.CloseOn(date)
+		if !closeAt.After(now) {
+			return nil, rejectOrder(RejectInvalidExpiry, "expire_date %s has already closed", req.ExpireDate)
+		}
+		if closeAt.After(now.AddDate(0, 0, maxGTDDays)) {
+			return nil, rejectOrder(RejectInvalidExpiry, "expire_date must be within %d days", maxGTDDays)
+		}
+		return &closeAt, nil
+	}
+	return nil, nil
+}
+
+// NewOrderID returns a unique, roughly time-ordered order identifier
+func NewOrderID() string {
+	return newID("ORD")
+}
+
+// newID returns a unique, roughly time-ordered identifier with the prefix
+func newID(prefix string) string {
+	b := make([]byte, 4)
+	if _, err := rand.Read(b); err != nil {
+		return fmt.Sprintf("%s%d", prefix, time.Now().UnixNano())
+	}
+	return prefix + strings.ToUpper(strconv.FormatInt(time.Now().UnixMilli(), 36)+hex.EncodeToString(b))
+}
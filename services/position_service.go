@@ -0,0 +1,185 @@
+package services
+
+import (
+	"context"
+	"sort"
+	"trading-platform-backend/models"
+	"trading-platform-backend/repository"
+)
+
+// PositionService nets users' fills into positions marked to market
+type PositionService struct {
+	orders   repository.OrderRepository
+	prices   *PriceSimulator
+	calendar *MarketCalendar
+}
+
+func NewPositionService(orders repository.OrderRepository, prices *PriceSimulator, calendar *MarketCalendar) *PositionService {
+	return &PositionService{
+		orders:   orders,
+		prices:   prices,
+		calendar: calendar,
+	}
+}
+
+// Portfolio is a user's open positions with the day's P&L across every
+// symbol traded, including positions closed since the previous close
+type Portfolio struct {
+	Positions     []models.Position
+	DayPNL        float64
+	RealizedPNL   float64 // across every symbol ever traded
+	UnrealizedPNL float64
+}
+
+// Position returns the open position in symbol, if any
+func (p *Portfolio) Position(symbol string) (models.Position, bool) {
+	for _, position := range p.Positions {
+		if position.Symbol == symbol {
+			return position, true
+		}
+	}
+	return models.Position{}, false
+}
+
+// NetQuantity returns the signed quantity held in symbol: positive when
+// long, negative when short
+func (p *Portfolio) NetQuantity(symbol string) int {
+	position, ok := p.Position(symbol)
+	if !ok {
+		return 0
+	}
+	if position.PositionType == models.PositionShort {
+		return -position.Quantity
+	}
+	return position.Quantity
+}
+
+// Portfolio builds the user's positions from their fills
+func (s *PositionService) Portfolio(ctx context.Context, userID uint) (*Portfolio, error) {
+	orders, err := s.orders.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.portfolio(orders), nil
+}
+
+// ledger tracks one symbol's net position at average cost
+type ledger struct {
+	quantity     int // signed
+	averagePrice float64
+	realized     float64
+	openingQty   int     // signed quantity held at the start of the day
+	dayCashFlow  float64 // sale proceeds less purchase cost of today's fills
+}
+
+// fill applies a trade of signed quantity at price. Trades against the
+// position realize P&L at average cost; a trade through zero opens the
+// opposite side at price.
+func (l *ledger) fill(quantity int, price float64) {
+	if l.quantity == 0 || (l.quantity > 0) == (quantity > 0) {
+		total := abs(l.quantity) + abs(quantity)
+		l.averagePrice = (l.averagePrice*float64(abs(l.quantity)) + price*float64(abs(quantity))) / float64(total)
+		l.quantity += quantity
+		return
+	}
+
+	closed := min(abs(quantity), abs(l.quantity))
+	direction := 1.0
+	if l.quantity < 0 {
+		direction = -1
+	}
+	l.realized += direction * (price - l.averagePrice) * float64(closed)
+	l.quantity += quantity
+	switch {
+	case l.quantity == 0:
+		l.averagePrice = 0
+	case (l.quantity > 0) == (quantity > 0):
+		l.averagePrice = price
+	}
+}
+
+// portfolio replays filled orders in execution order. Day P&L marks each
+// symbol from the previous close, or from the fill price for today's trades.
+func (s *PositionService) portfolio(orders []models.Order) *Portfolio {
+	var fills []models.Order
+	for _, order := range orders {
+		if order.FilledQuantity > 0 && order.ExecutedTime != nil {
+			fills = append(fills, order)
+		}
+	}
+	sort.SliceStable(fills, func(i, j int) bool {
+		return fills[i].ExecutedTime.Before(*fills[j].ExecutedTime)
+	})
+
+	now := s.calendar.Now().In(s.calendar.Location())
+	dayStart := startOfDay(now)
+
+	ledgers := make(map[string]*ledger)
+	var symbols []string
+	for _, order := range fills {
+		l, ok := ledgers[order.Symbol]
+		if !ok {
+			l = &ledger{}
+			ledgers[order.Symbol] = l
+			symbols = append(symbols, order.Symbol)
+		}
+
+		quantity := order.FilledQuantity
+		if order.Side == models.SideSell {
+			quantity = -quantity
+		}
+		l.fill(quantity, order.AveragePrice)
+		if order.ExecutedTime.Before(dayStart) {
+			l.openingQty = l.quantity
+		} else {
+			l.dayCashFlow -= float64(quantity) * order.AveragePrice
+		}
+	}
+	sort.Strings(symbols)
+
+	portfolio := &Portfolio{}
+	for _, symbol := range symbols {
+		l := ledgers[symbol]
+		last, _ := s.prices.LastPrice(symbol)
+		reference := last
+		if snapshot, ok := s.prices.Snapshot(symbol); ok && snapshot.PrevClose > 0 {
+			reference = snapshot.PrevClose
+		}
+		dayPNL := float64(l.quantity)*last - float64(l.openingQty)*reference + l.dayCashFlow
+		portfolio.DayPNL += dayPNL
+		portfolio.RealizedPNL += l.realized
+		if l.quantity == 0 {
+			continue
+		}
+
+		position := models.Position{
+			Symbol:       symbol,
+			Quantity:     abs(l.quantity),
+			AveragePrice: round2(l.averagePrice),
+			CurrentPrice: last,
+			RealizedPNL:  round2(l.realized),
+			DayPNL:       round2(dayPNL),
+			PositionType: models.PositionLong,
+		}
+		direction := 1.0
+		if l.quantity < 0 {
+			position.PositionType = models.PositionShort
+			direction = -1
+		}
+		position.UnrealizedPNL = round2(direction * (last - l.averagePrice) * float64(position.Quantity))
+		position.UnrealizedPNLPercent = round2(direction * (last - l.averagePrice) / l.averagePrice * 100)
+		portfolio.UnrealizedPNL += direction * (last - l.averagePrice) * float64(position.Quantity)
+		portfolio.Positions = append(portfolio.Positions, position)
+	}
+	portfolio.DayPNL = round2(portfolio.DayPNL)
+	portfolio.RealizedPNL = round2(portfolio.RealizedPNL)
+	portfolio.UnrealizedPNL = round2(portfolio.UnrealizedPNL)
+	return portfolio
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
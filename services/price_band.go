@@ -0,0 +1,36 @@
+package services
+
+import "math"
+
+// DefaultPriceBand is the band applied to instruments that do not specify one,
+// in percent of the previous close
+const DefaultPriceBand = 20
+
+// priceEpsilon absorbs floating point noise when comparing prices to ticks
+const priceEpsilon = 1e-6
+
+// PriceBand returns the lowest and highest prices allowed for the day: the
+// reference price moved by bandPercent, rounded inwards to the tick size. It
+// reports false when no band applies.
+func PriceBand(reference, bandPercent, tickSize float64) (lower, upper float64, ok bool) {
+	if bandPercent <= 0 || reference <= 0 {
+		return 0, 0, false
+	}
+
+	lower = reference * (1 - bandPercent/100)
+	upper = reference * (1 + bandPercent/100)
+	if tickSize > 0 {
+		lower = roundToTick(math.Ceil(lower/tickSize-priceEpsilon)*tickSize, tickSize)
+		upper = roundToTick(math.Floor(upper/tickSize+priceEpsilon)*tickSize, tickSize)
+	}
+	return lower, upper, true
+}
+
+// OnTick reports whether price is a whole multiple of tickSize
+func OnTick(price, tickSize float64) bool {
+	if tickSize <= 0 {
+		return true
+	}
+	ticks := price / tickSize
+	return math.Abs(ticks-math.Round(ticks)) < priceEpsilon*math.Max(1, ticks)
+}
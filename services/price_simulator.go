@@ -0,0 +1,268 @@
+package services
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"slices"
+	"sync"
+	"time"
+	"trading-platform-backend/config"
+	"trading-platform-backend/models"
+)
+
+// Tick is one simulated last-traded-price update
+type Tick struct {
+	Symbol string
+	Price  float64
+	Volume int
+	Time   time.Time
+}
+
+// MarketSnapshot is the current session's trading summary for a symbol
+type MarketSnapshot struct {
+	Symbol    string
+	LastPrice float64
+	Open      float64
+	High      float64
+	Low       float64
+	PrevClose float64
+	Volume    int64
+	UpdatedAt time.Time
+}
+
+// PriceSimulator generates a random walk of last traded prices for every
+// active instrument in the instrument master while the market is in its
+// normal session. It is the platform's price source until a real market data
+// feed is connected.
+type PriceSimulator struct {
+	instruments *InstrumentService
+	calendar    *MarketCalendar
+	config      config.SimulatorConfig
+
+	mu          sync.RWMutex
+	prices      map[string]float64
+	sessions    map[string]*MarketSnapshot
+	sessionDate string               // exchange-local date of the sessions above
+	halts       map[string]time.Time // symbol -> end of circuit halt
+	rng         *rand.Rand
+	subscribers []func(Tick)
+}
+
+func NewPriceSimulator(instruments *InstrumentService, calendar *MarketCalendar, cfg config.SimulatorConfig) *PriceSimulator {
+	return &PriceSimulator{
+		instruments: instruments,
+		calendar:    calendar,
+		config:      cfg,
+		prices:      make(map[string]float64),
+		sessions:    make(map[string]*MarketSnapshot),
+		halts:       make(map[string]time.Time),
+		rng:         rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// Subscribe registers fn to receive every tick. Subscribers run on the
+// simulator goroutine and must not block.
+func (s *PriceSimulator) Subscribe(fn func(Tick)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.subscribers = append(s.subscribers, fn)
+}
+
+// LastPrice returns the latest simulated price, falling back to the
+// instrument's previous close before the first tick
+func (s *PriceSimulator) LastPrice(symbol string) (float64, bool) {
+	s.mu.RLock()
+	price, ok := s.prices[symbol]
+	s.mu.RUnlock()
+	if ok {
+		return price, true
+	}
+
+	if instrument, ok := s.instruments.Get(symbol); ok && instrument.PrevClose > 0 {
+		return instrument.PrevClose, true
+	}
+	return 0, false
+}
+
+// Snapshot returns the session summary for symbol. Before the first tick of
+// a trading day every price equals the previous close and volume is zero.
+func (s *PriceSimulator) Snapshot(symbol string) (MarketSnapshot, bool) {
+	s.mu.RLock()
+	session, ok := s.sessions[symbol]
+	var snapshot MarketSnapshot
+	if ok {
+		snapshot = *session
+	}
+	s.mu.RUnlock()
+	if ok {
+		return snapshot, true
+	}
+
+	instrument, ok := s.instruments.Get(symbol)
+	if !ok {
+		return MarketSnapshot{}, false
+	}
+	p := instrument.PrevClose
+	return MarketSnapshot{Symbol: instrument.Symbol, LastPrice: p, Open: p, High: p, Low: p, PrevClose: p}, true
+}
+
+// Start ticks every configured interval until ctx is cancelled
+func (s *PriceSimulator) Start(ctx context.Context) {
+	ticker := time.NewTicker(s.config.TickInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.Step()
+		}
+	}
+}
+
+// Step advances every active instrument by one random tick. It does nothing
+// outside the normal market session.
+func (s *PriceSimulator) Step() {
+	now := s.calendar.Now()
+	if s.calendar.SessionAt(now) != SessionNormal {
+		return
+	}
+	var ticks []Tick
+
+	s.mu.Lock()
+	s.rollSessions(now)
+	for _, instrument := range s.instruments.List() {
+		if instrument.TradingStatus != models.InstrumentActive || instrument.PrevClose <= 0 {
+			continue
+		}
+		if until, ok := s.halts[instrument.Symbol]; ok {
+			if now.Before(until) {
+				continue
+			}
+			delete(s.halts, instrument.Symbol)
+		}
+
+		price, ok := s.prices[instrument.Symbol]
+		if !ok {
+			price = instrument.PrevClose
+		}
+
+		price = roundToTick(price*(1+s.rng.NormFloat64()*s.config.Volatility), instrument.TickSize)
+		if price < instrument.TickSize {
+			price = instrument.TickSize
+		}
+
+		// A price reaching its band is held there and halts the instrument
+		if lower, upper, ok := PriceBand(s.referencePrice(instrument), instrument.PriceBand, instrument.TickSize); ok {
+			if price <= lower || price >= upper {
+				price = min(max(price, lower), upper)
+				if s.config.CircuitHalt > 0 {
+					s.halts[instrument.Symbol] = now.Add(s.config.CircuitHalt)
+				}
+			}
+		}
+		s.prices[instrument.Symbol] = price
+
+		tick := Tick{
+			Symbol: instrument.Symbol,
+			Price:  price,
+			Volume: instrument.LotSize * (1 + s.rng.Intn(50)),
+			Time:   now,
+		}
+		s.recordSession(instrument, tick)
+		ticks = append(ticks, tick)
+	}
+	subscribers := slices.Clone(s.subscribers)
+	s.mu.Unlock()
+
+	for _, tick := range ticks {
+		for _, fn := range subscribers {
+			fn(tick)
+		}
+	}
+}
+
+// referencePrice is the previous close the day's price band is based on;
+// callers hold mu
+func (s *PriceSimulator) referencePrice(instrument models.Instrument) float64 {
+	if session, ok := s.sessions[instrument.Symbol]; ok && session.PrevClose > 0 {
+		return session.PrevClose
+	}
+	return instrument.PrevClose
+}
+
+// Band returns the day's lower and upper price limits for symbol, and false
+// when the instrument is unknown or has no band
+func (s *PriceSimulator) Band(symbol string) (lower, upper float64, ok bool) {
+	instrument, ok := s.instruments.Get(symbol)
+	if !ok {
+		return 0, 0, false
+	}
+
+	s.mu.RLock()
+	reference := s.referencePrice(instrument)
+	s.mu.RUnlock()
+	return PriceBand(reference, instrument.PriceBand, instrument.TickSize)
+}
+
+// HaltedUntil reports whether symbol is in a circuit halt and when it ends
+func (s *PriceSimulator) HaltedUntil(symbol string) (time.Time, bool) {
+	now := s.calendar.Now()
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	until, ok := s.halts[symbol]
+	if !ok || !now.Before(until) {
+		return time.Time{}, false
+	}
+	return until, true
+}
+
+// rollSessions starts fresh session summaries on a new trading day, carrying
+// the last price over as the previous close; callers hold mu
+func (s *PriceSimulator) rollSessions(now time.Time) {
+	date := now.In(s.calendar.Location()).Format(time.DateOnly)
+	if date == s.sessionDate {
+		return
+	}
+	if s.sessionDate != "" {
+		sessions := make(map[string]*MarketSnapshot, len(s.sessions))
+		for symbol, last := range s.sessions {
+			p := last.LastPrice
+			sessions[symbol] = &MarketSnapshot{Symbol: symbol, LastPrice: p, Open: p, High: p, Low: p, PrevClose: p}
+		}
+		s.sessions = sessions
+		clear(s.halts)
+	}
+	s.sessionDate = date
+}
+
+// recordSession folds a tick into the symbol's session summary; callers hold mu
+func (s *PriceSimulator) recordSession(instrument models.Instrument, tick Tick) {
+	session, ok := s.sessions[tick.Symbol]
+	if !ok {
+		session = &MarketSnapshot{Symbol: tick.Symbol, PrevClose: instrument.PrevClose}
+		s.sessions[tick.Symbol] = session
+	}
+	if session.Volume == 0 {
+		session.Open, session.High, session.Low = tick.Price, tick.Price, tick.Price
+	}
+
+	session.LastPrice = tick.Price
+	session.High = max(session.High, tick.Price)
+	session.Low = min(session.Low, tick.Price)
+	session.Volume += int64(tick.Volume)
+	session.UpdatedAt = tick.Time
+}
+
+// roundToTick rounds price to the nearest multiple of tickSize
+func roundToTick(price, tickSize float64) float64 {
+	if tickSize <= 0 {
+		return price
+	}
+	ticks := math.Round(price / tickSize)
+	// Round again to remove floating point noise such as 2485.2000000000003
+	return math.Round(ticks*tickSize*1e6) / 1e6
+}
@@ -0,0 +1,197 @@
+package services
+
+import (
+	"context"
+	"math"
+	"trading-platform-backend/config"
+	"trading-platform-backend/logger"
+	"trading-platform-backend/metrics"
+	"trading-platform-backend/models"
+	"trading-platform-backend/repository"
+)
+
+// Pre-trade risk rejection codes returned to clients in ErrorResponse.Code
+const (
+	RejectRiskRestricted     = "RISK_RESTRICTED_SYMBOL"
+	RejectRiskQuantity       = "RISK_MAX_QUANTITY"
+	RejectRiskOrderValue     = "RISK_MAX_ORDER_VALUE"
+	RejectRiskPriceDeviation = "RISK_PRICE_DEVIATION"
+	RejectRiskOpenOrders     = "RISK_MAX_OPEN_ORDERS"
+	RejectRiskPosition       = "RISK_POSITION_LIMIT"
+	RejectRiskDailyLoss      = "RISK_DAILY_LOSS_LIMIT"
+)
+
+// RiskService runs pre-trade checks against the limits configured for each
+// user's role and email, then checks the order's margin
+type RiskService struct {
+	users      repository.UserRepository
+	orders     repository.OrderRepository
+	positions  *PositionService
+	margins    *MarginService
+	prices     *PriceSimulator
+	cfgManager *config.Manager
+}
+
+func NewRiskService(users repository.UserRepository, orders repository.OrderRepository, positions *PositionService, margins *MarginService, prices *PriceSimulator, cfgManager *config.Manager) *RiskService {
+	return &RiskService{
+		users:      users,
+		orders:     orders,
+		positions:  positions,
+		margins:    margins,
+		prices:     prices,
+		cfgManager: cfgManager,
+	}
+}
+
+// riskAccount is what the checks know about the user placing an order
+type riskAccount struct {
+	lastPrice  float64
+	openOrders []models.Order
+	portfolio  *Portfolio
+}
+
+// riskCheck returns a rejection when order breaches one of limits
+type riskCheck func(order *models.Order, limits config.RiskLimits, account *riskAccount) *OrderError
+
+// riskChecks run in order; the first breach rejects the order
+var riskChecks = []riskCheck{
+	checkRestrictedSymbol,
+	checkOrderQuantity,
+	checkOrderValue,
+	checkPriceDeviation,
+	checkOpenOrders,
+	checkPositionLimit,
+	checkDailyLoss,
+}
+
+// Check runs every pre-trade check on an order the user is about to place
+// and returns an *OrderError with a RISK_ code for the first limit breached,
+// or INSUFFICIENT_MARGIN when the account cannot carry the order
+func (s *RiskService) Check(ctx context.Context, userID uint, order *models.Order) error {
+	user, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	limits := s.cfgManager.Current().RiskConfig.LimitsFor(user.Email, user.Role)
+
+	orders, err := s.orders.ListByUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	account := &riskAccount{portfolio: s.positions.portfolio(orders)}
+	account.lastPrice, _ = s.prices.LastPrice(order.Symbol)
+	for _, o := range orders {
+		if o.IsOpen() {
+			account.openOrders = append(account.openOrders, o)
+		}
+	}
+
+	var rejection *OrderError
+	for _, check := range riskChecks {
+		if rejection = check(order, limits, account); rejection != nil {
+			break
+		}
+	}
+	if rejection == nil {
+		rejection = s.margins.check(order, orders, account.portfolio)
+	}
+	if rejection == nil {
+		return nil
+	}
+	metrics.RiskRejectionsTotal.WithLabelValues(rejection.Code).Inc()
+	logger.FromContext(ctx).Info("Order rejected by risk check",
+		"user_id", userID, "symbol", order.Symbol, "code", rejection.Code, "reason", rejection.Message)
+	return rejection
+}
+
+func checkRestrictedSymbol(order *models.Order, limits config.RiskLimits, _ *riskAccount) *OrderError {
+	if limits.IsRestricted(order.Symbol) {
+		return rejectOrder(RejectRiskRestricted, "%s is restricted for your account", order.Symbol)
+	}
+	return nil
+}
+
+func checkOrderQuantity(order *models.Order, limits config.RiskLimits, _ *riskAccount) *OrderError {
+	if limits.MaxOrderQuantity > 0 && order.Quantity > limits.MaxOrderQuantity {
+		return rejectOrder(RejectRiskQuantity, "quantity %d exceeds the limit of %d per order", order.Quantity, limits.MaxOrderQuantity)
+	}
+	return nil
+}
+
+// checkOrderValue values the order at its limit price, else its trigger
+// price, else the last price
+func checkOrderValue(order *models.Order, limits config.RiskLimits, account *riskAccount) *OrderError {
+	if limits.MaxOrderValue <= 0 {
+		return nil
+	}
+	price := order.Price
+	if price == 0 {
+		price = order.TriggerPrice
+	}
+	if price == 0 {
+		price = account.lastPrice
+	}
+	if value := price * float64(order.Quantity); value > limits.MaxOrderValue {
+		return rejectOrder(RejectRiskOrderValue, "order value %.2f exceeds the limit of %.2f", value, limits.MaxOrderValue)
+	}
+	return nil
+}
+
+// checkPriceDeviation catches fat-fingered limit prices far from the market
+func checkPriceDeviation(order *models.Order, limits config.RiskLimits, account *riskAccount) *OrderError {
+	if limits.MaxPriceDeviation <= 0 || order.Price == 0 || account.lastPrice <= 0 {
+		return nil
+	}
+	if deviation := math.Abs(order.Price-account.lastPrice) / account.lastPrice * 100; deviation > limits.MaxPriceDeviation {
+		return rejectOrder(RejectRiskPriceDeviation, "price %g is %.1f%% from the last price %g; the limit is %g%%",
+			order.Price, deviation, account.lastPrice, limits.MaxPriceDeviation)
+	}
+	return nil
+}
+
+func checkOpenOrders(_ *models.Order, limits config.RiskLimits, account *riskAccount) *OrderError {
+	if limits.MaxOpenOrders > 0 && len(account.openOrders) >= limits.MaxOpenOrders {
+		return rejectOrder(RejectRiskOpenOrders, "you already have %d open orders; the limit is %d", len(account.openOrders), limits.MaxOpenOrders)
+	}
+	return nil
+}
+
+// checkPositionLimit assumes every open order on the same side fills too
+func checkPositionLimit(order *models.Order, limits config.RiskLimits, account *riskAccount) *OrderError {
+	if limits.MaxPositionQuantity <= 0 {
+		return nil
+	}
+	exposure := order.Quantity
+	for _, open := range account.openOrders {
+		if open.Symbol == order.Symbol && open.Side == order.Side {
+			exposure += open.Quantity - open.FilledQuantity
+		}
+	}
+
+	net := account.portfolio.NetQuantity(order.Symbol)
+	projected := net + exposure
+	if order.Side == models.SideSell {
+		projected = exposure - net
+	}
+	if projected > limits.MaxPositionQuantity {
+		return rejectOrder(RejectRiskPosition, "the order could take your %s position to %d; the limit is %d",
+			order.Symbol, projected, limits.MaxPositionQuantity)
+	}
+	return nil
+}
+
+// checkDailyLoss allows only orders that reduce a position once the day's
+// loss reaches the limit
+func checkDailyLoss(order *models.Order, limits config.RiskLimits, account *riskAccount) *OrderError {
+	if limits.DailyLossLimit <= 0 || account.portfolio.DayPNL > -limits.DailyLossLimit {
+		return nil
+	}
+	net := account.portfolio.NetQuantity(order.Symbol)
+	reducing := (order.Side == models.SideBuy && net < 0 && order.Quantity <= -net) ||
+		(order.Side == models.SideSell && net > 0 && order.Quantity <= net)
+	if !reducing {
+		return rejectOrder(RejectRiskDailyLoss, "today's loss of %.2f has reached the limit of %.2f; only orders that reduce a position are allowed",
+			-account.portfolio.DayPNL, limits.DailyLossLimit)
+	}
+	return nil
+}
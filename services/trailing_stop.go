@@ -0,0 +1,40 @@
+package services
+
+import (
+	"math"
+	"trading-platform-backend/models"
+)
+
+// TrailingTrigger returns where a trailing stop's trigger sits when the best
+// price seen is price: below it for a sell, above it for a buy, rounded away
+// from the market to the tick size
+func TrailingTrigger(order *models.Order, price, tickSize float64) float64 {
+	offset := order.TrailAmount
+	if order.TrailPercent > 0 {
+		offset = price * order.TrailPercent / 100
+	}
+
+	trigger := price + offset
+	if order.Side == models.SideSell {
+		trigger = price - offset
+	}
+	if tickSize <= 0 {
+		return trigger
+	}
+	if order.Side == models.SideSell {
+		return roundToTick(math.Floor(trigger/tickSize+priceEpsilon)*tickSize, tickSize)
+	}
+	return roundToTick(math.Ceil(trigger/tickSize-priceEpsilon)*tickSize, tickSize)
+}
+
+// trail moves a trailing stop's trigger after a favorable price move and
+// reports whether it moved. The trigger never moves back.
+func trail(order *models.Order, price, tickSize float64) bool {
+	trigger := TrailingTrigger(order, price, tickSize)
+	if (order.Side == models.SideSell && trigger > order.TriggerPrice) ||
+		(order.Side == models.SideBuy && trigger < order.TriggerPrice) {
+		order.TriggerPrice = trigger
+		return true
+	}
+	return false
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"trading-platform-backend/services"
+)
+
+const tokenUsage = `Usage: trading-platform-backend token inspect <token>
+
+Decodes the JWT and verifies it against the configured access and refresh
+secrets. Exits with status 1 when the token does not verify.`
+
+func runToken(args []string) {
+	if len(args) != 2 || args[0] != "inspect" {
+		fmt.Fprintln(os.Stderr, tokenUsage)
+		os.Exit(2)
+	}
+
+	// Verification only needs the secrets, not the database
+	cfg, _ := loadConfig()
+	authService := services.NewAuthService(nil, cfg)
+
+	inspection, err := authService.InspectToken(args[1])
+	if err != nil {
+		fatal("Failed to decode token", err)
+	}
+
+	out, _ := json.MarshalIndent(inspection, "", "  ")
+	fmt.Println(string(out))
+
+	if !inspection.Valid {
+		os.Exit(1)
+	}
+}
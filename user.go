@@ -0,0 +1,101 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"trading-platform-backend/config"
+	"trading-platform-backend/repository"
+	"trading-platform-backend/services"
+)
+
+const userUsage = `Usage: trading-platform-backend user <command> -email <email> [-password <password>] [-role <role>]
+
+Commands:
+  create          create a user account
+  disable         block login and token refresh for a user
+  enable          re-enable a disabled user
+  reset-password  set a new password
+  set-role        set the role (trader or admin) that selects risk limits
+
+When -password is omitted for create or reset-password it is read from stdin.`
+
+func runUser(args []string) {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, userUsage)
+		os.Exit(2)
+	}
+
+	command := args[0]
+	flags := flag.NewFlagSet("user "+command, flag.ExitOnError)
+	email := flags.String("email", "", "user email address")
+	password := flags.String("password", "", "password (read from stdin when omitted)")
+	role := flags.String("role", "", "role for set-role (trader or admin)")
+	flags.Parse(args[1:])
+
+	if *email == "" {
+		fmt.Fprintln(os.Stderr, "-email is required")
+		os.Exit(2)
+	}
+
+	cfg, _ := loadConfig()
+	authService := newCLIAuthService(cfg)
+	ctx := context.Background()
+
+	switch command {
+	case "create":
+		user, err := authService.CreateUser(ctx, *email, readPassword(*password))
+		if err != nil {
+			fatal("Failed to create user", err)
+		}
+		fmt.Printf("Created user %d (%s)\n", user.ID, user.Email)
+	case "disable", "enable":
+		user, err := authService.SetUserDisabled(ctx, *email, command == "disable")
+		if err != nil {
+			fatal("Failed to update user", err)
+		}
+		fmt.Printf("User %d (%s) %sd\n", user.ID, user.Email, command)
+	case "reset-password":
+		if err := authService.ResetPassword(ctx, *email, readPassword(*password)); err != nil {
+			fatal("Failed to reset password", err)
+		}
+		fmt.Printf("Password reset for %s\n", *email)
+	case "set-role":
+		user, err := authService.SetUserRole(ctx, *email, *role)
+		if err != nil {
+			fatal("Failed to update user", err)
+		}
+		fmt.Printf("User %d (%s) is now %s\n", user.ID, user.Email, user.Role)
+	default:
+		fmt.Fprintln(os.Stderr, userUsage)
+		os.Exit(2)
+	}
+}
+
+// newCLIAuthService builds an AuthService for offline administration
+func newCLIAuthService(cfg *config.Config) *services.AuthService {
+	db := openDatabase(cfg)
+	return services.NewAuthService(repository.NewGormUserRepository(db), cfg)
+}
+
+// readPassword returns the flag value or reads one line from stdin, enforcing
+// the same minimum length as the signup endpoint
+func readPassword(password string) string {
+	if password == "" {
+		fmt.Fprint(os.Stderr, "Password: ")
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && line == "" {
+			fatal("Failed to read password", err)
+		}
+		password = strings.TrimRight(line, "\r\n")
+	}
+
+	if len(password) < 6 {
+		fmt.Fprintln(os.Stderr, "password must be at least 6 characters")
+		os.Exit(2)
+	}
+	return password
+}